@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"github.com/jkeene-NAN/sudoku/game"
+	"io"
+	"log"
+	"os"
+)
+
+/*
+Usage: sudoku booklet [-o booklet.pdf] [-per-page 4] [-answers-per-page 9] [-title Sudoku] puzzles...
+
+Every puzzle in the named files, in any of the supported formats, goes into the booklet with its
+solution in the answer key.  Names and ratings stored next to one puzzle per line become the titles
+and difficulty labels.
+*/
+func booklet(args []string) {
+	var flags *flag.FlagSet = flag.NewFlagSet("booklet", flag.ExitOnError)
+	var output = flags.String("o", "booklet.pdf", "file to write the booklet to")
+	var perPage = flags.Int("per-page", game.DefaultPuzzlesPerPage, "puzzles on each page")
+	var answersPerPage = flags.Int("answers-per-page", game.DefaultAnswersPerPage, "solutions on each answer key page")
+	var title = flags.String("title", "Sudoku", "title printed at the top of the puzzle pages")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		log.Fatal("booklet needs at least one puzzle file")
+	}
+
+	var solver *game.Solver = game.CreateSolver()
+	var entries []*game.BookletPuzzle = make([]*game.BookletPuzzle, 0)
+	for _, fileName := range flags.Args() {
+		for _, puzzle := range readPuzzles(fileName) {
+			solution, _, err := solver.Solve(puzzle.Game)
+			if err != nil {
+				log.Printf("%s line %d: no solution in the answer key: %v", fileName, puzzle.Line, err)
+				solution = nil
+			}
+			entries = append(entries, &game.BookletPuzzle{
+				Title:      puzzle.Name,
+				Difficulty: puzzle.Rating,
+				Puzzle:     puzzle.Game,
+				Solution:   solution,
+			})
+		}
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("error creating booklet: %v", err)
+	}
+	defer file.Close()
+
+	err = game.WriteBooklet(file, entries, &game.BookletOptions{
+		Title:          *title,
+		PuzzlesPerPage: *perPage,
+		AnswersPerPage: *answersPerPage,
+	})
+	if err != nil {
+		log.Fatalf("error writing booklet: %v", err)
+	}
+	log.Printf("wrote %d puzzles to %s", len(entries), *output)
+}
+
+/*
+readPuzzles reads every puzzle in a file, skipping lines that do not parse.
+*/
+func readPuzzles(fileName string) []*game.Puzzle {
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatalf("error opening puzzles: %v", err)
+	}
+	defer file.Close()
+
+	var format game.Format = game.FormatForFileName(fileName)
+	if format == game.SadManFormat || format == game.SimpleSudokuFormat {
+		games, err := game.ReadFormat(file, format)
+		if err != nil {
+			log.Fatalf("error reading %s: %v", fileName, err)
+		}
+		return []*game.Puzzle{{Game: games[0]}}
+	}
+
+	var puzzles []*game.Puzzle = make([]*game.Puzzle, 0)
+	var reader *game.PuzzleReader = game.NewPuzzleReader(file)
+	for {
+		puzzle, err := reader.Next()
+		if err == io.EOF {
+			return puzzles
+		}
+		if err != nil {
+			if puzzle == nil {
+				log.Fatalf("error reading %s: %v", fileName, err)
+			}
+			log.Printf("%s: skipping puzzle: %v", fileName, err)
+			continue
+		}
+		puzzles = append(puzzles, puzzle)
+	}
+}
@@ -0,0 +1,345 @@
+package game
+
+import (
+	"math/bits"
+)
+
+/*
+The almost locked set techniques come after the chains.  An almost locked set is a group of cells
+in one unit with one more candidate than cells: take any one value away and the rest are locked in.
+*/
+var (
+	ALSXZ        = Technique{Name: "ALS-XZ", Difficulty: 75, find: findALSXZ}
+	ALSXYWing    = Technique{Name: "ALS-XY-Wing", Difficulty: 80, find: findALSXYWing}
+	DeathBlossom = Technique{Name: "Death Blossom", Difficulty: 85, find: findDeathBlossom}
+)
+
+/*
+maxALSSize bounds the cells of the almost locked sets the techniques look for, to keep the search
+quick; bigger ones are rarely needed.
+*/
+const maxALSSize = 4
+
+/*
+cellSet is a set of cells stored as a bit mask, bit row*numColumns+column standing for a cell.
+*/
+type cellSet [(numRows*numColumns + 63) / 64]uint64
+
+func (set *cellSet) add(cell Cell) {
+	var bit int = cell.Row*numColumns + cell.Column
+	set[bit/64] |= 1 << (bit % 64)
+}
+
+func (set cellSet) has(cell Cell) bool {
+	var bit int = cell.Row*numColumns + cell.Column
+	return set[bit/64]&(1<<(bit%64)) != 0
+}
+
+func (set cellSet) or(other cellSet) cellSet {
+	for i := range set {
+		set[i] |= other[i]
+	}
+
+	return set
+}
+
+func (set cellSet) empty() bool {
+	for _, word := range set {
+		if word != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+overlaps reports whether the two sets share a cell.
+*/
+func (set cellSet) overlaps(other cellSet) bool {
+	for i := range set {
+		if set[i]&other[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+within reports whether every cell of set is in other.
+*/
+func (set cellSet) within(other cellSet) bool {
+	for i := range set {
+		if set[i]&^other[i] != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (set cellSet) each(visit func(cell Cell)) {
+	for i, word := range set {
+		for rest := word; rest != 0; rest &= rest - 1 {
+			var bit int = i*64 + bits.TrailingZeros64(rest)
+			visit(Cell{Row: bit / numColumns, Column: bit % numColumns})
+		}
+	}
+}
+
+/*
+peerSets holds peers as cell sets.
+*/
+var peerSets [numRows * numColumns]cellSet = func() [numRows * numColumns]cellSet {
+	var ret [numRows * numColumns]cellSet
+	for i, list := range peers {
+		for _, peer := range list {
+			ret[i].add(peer)
+		}
+	}
+
+	return ret
+}()
+
+/*
+almostLockedSet is a group of cells with one more candidate between them than cells.  Positions
+holds the cells of the group with each value as a candidate.
+*/
+type almostLockedSet struct {
+	cells     []Cell
+	set       cellSet
+	values    digitSet
+	positions [numCandidates]cellSet
+}
+
+/*
+almostLockedSets returns every almost locked set of up to maxALSSize empty cells, each once even
+when its cells share more than one unit.
+*/
+func (grid *candidateGrid) almostLockedSets() []*almostLockedSet {
+	var ret []*almostLockedSet
+	var seen map[cellSet]bool = make(map[cellSet]bool)
+	for unit := 0; unit < numUnits; unit++ {
+		var open []Cell = make([]Cell, 0, numCandidates)
+		for _, cell := range units[unit] {
+			if grid.empty(cell) {
+				open = append(open, cell)
+			}
+		}
+		for size := 1; size <= maxALSSize && size < len(open); size++ {
+			combinations(len(open), size, func(indexes []int) bool {
+				var als *almostLockedSet = &almostLockedSet{cells: make([]Cell, size)}
+				for i, index := range indexes {
+					als.cells[i] = open[index]
+					als.set.add(open[index])
+					als.values |= grid.get(open[index])
+				}
+				if als.values.count() != size+1 || seen[als.set] {
+					return false
+				}
+				seen[als.set] = true
+				for _, cell := range als.cells {
+					for _, value := range grid.get(cell).values() {
+						als.positions[value].add(cell)
+					}
+				}
+				ret = append(ret, als)
+				return false
+			})
+		}
+	}
+
+	return ret
+}
+
+/*
+restrictedCommons returns the values of two almost locked sets without cells in common that cannot
+be in both, because every cell of one with the value sees every cell of the other with it.
+*/
+func restrictedCommons(a, b *almostLockedSet) digitSet {
+	var ret digitSet = 0
+	if a.set.overlaps(b.set) {
+		return ret
+	}
+	for _, value := range (a.values & b.values).values() {
+		var restricted bool = true
+		a.positions[value].each(func(cell Cell) {
+			restricted = restricted && b.positions[value].within(peerSets[cell.Row*numColumns+cell.Column])
+		})
+		if restricted {
+			ret = ret.add(value)
+		}
+	}
+
+	return ret
+}
+
+/*
+eliminationsSeeing lists value for every cell outside of exclude that has it as a candidate and
+sees all of cells.
+*/
+func (grid *candidateGrid) eliminationsSeeing(value int, cells cellSet, exclude cellSet) []CellDigit {
+	var ret []CellDigit
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var cell Cell = Cell{Row: row, Column: column}
+			if grid.get(cell).has(value) && !exclude.has(cell) && cells.within(peerSets[row*numColumns+column]) {
+				ret = append(ret, CellDigit{Cell: cell, Digit: value + 1})
+			}
+		}
+	}
+
+	return ret
+}
+
+/*
+alsStep returns the step for the almost locked sets, nil when it removes nothing.
+*/
+func alsStep(sets []*almostLockedSet, values []int, eliminations []CellDigit) *Step {
+	if len(eliminations) == 0 {
+		return nil
+	}
+
+	var cells []Cell
+	for _, als := range sets {
+		cells = append(cells, als.cells...)
+	}
+	var digits []int = make([]int, len(values))
+	for i, value := range values {
+		digits[i] = value + 1
+	}
+
+	return &Step{Cells: cells, Digits: digits, Eliminations: eliminations}
+}
+
+/*
+findALSXZ looks for two almost locked sets with a restricted common value x.  At most one of them
+holds x, so the other is locked, and a second value z they share is in one or the other: z comes
+out of every cell that sees all their cells with z.
+*/
+func findALSXZ(grid *candidateGrid) *Step {
+	var sets []*almostLockedSet = grid.almostLockedSets()
+	for i, a := range sets {
+		for _, b := range sets[i+1:] {
+			var restricted digitSet = restrictedCommons(a, b)
+			for _, x := range restricted.values() {
+				for _, z := range (a.values & b.values).remove(x).values() {
+					var eliminations []CellDigit = grid.eliminationsSeeing(z, a.positions[z].or(b.positions[z]), a.set.or(b.set))
+					if step := alsStep([]*almostLockedSet{a, b}, []int{x, z}, eliminations); step != nil {
+						return step
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+findALSXYWing looks for a pivot almost locked set C with a restricted common value x with a set A
+and another, y, with a set B.  C cannot lack both x and y, so A or B is locked, and a value z the
+two share is in one or the other: z comes out of every cell that sees all their cells with z.
+*/
+func findALSXYWing(grid *candidateGrid) *Step {
+	var sets []*almostLockedSet = grid.almostLockedSets()
+	for _, c := range sets {
+		var linked []*almostLockedSet
+		var restricted []digitSet
+		for _, other := range sets {
+			if common := restrictedCommons(c, other); common != 0 {
+				linked = append(linked, other)
+				restricted = append(restricted, common)
+			}
+		}
+
+		for i, a := range linked {
+			for j := i + 1; j < len(linked); j++ {
+				var b *almostLockedSet = linked[j]
+				if a.set.overlaps(b.set) {
+					continue
+				}
+				for _, x := range restricted[i].values() {
+					for _, y := range restricted[j].remove(x).values() {
+						for _, z := range (a.values & b.values).remove(x).remove(y).values() {
+							var eliminations []CellDigit = grid.eliminationsSeeing(z, a.positions[z].or(b.positions[z]), a.set.or(b.set).or(c.set))
+							if step := alsStep([]*almostLockedSet{c, a, b}, []int{x, y, z}, eliminations); step != nil {
+								return step
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+findDeathBlossom looks for a stem cell and, for each of its candidates, a petal: an almost locked
+set whose cells with that value all see the stem.  Whichever value the stem takes, its petal is
+locked, so a value z every petal has, and the stem does not, is in one of them: z comes out of
+every cell that sees all the petals' cells with z.
+*/
+func findDeathBlossom(grid *candidateGrid) *Step {
+	var sets []*almostLockedSet = grid.almostLockedSets()
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var stem Cell = Cell{Row: row, Column: column}
+			var stemValues []int = grid.get(stem).values()
+			if len(stemValues) < 2 || len(stemValues) > 3 {
+				continue
+			}
+
+			var petals [][]*almostLockedSet = make([][]*almostLockedSet, len(stemValues))
+			for i, value := range stemValues {
+				for _, als := range sets {
+					if !als.set.has(stem) && als.values.has(value) && als.positions[value].within(peerSets[row*numColumns+column]) {
+						petals[i] = append(petals[i], als)
+					}
+				}
+			}
+
+			var chosen []*almostLockedSet = make([]*almostLockedSet, 0, len(stemValues))
+			var blossom func(common digitSet, used cellSet) *Step
+			blossom = func(common digitSet, used cellSet) *Step {
+				if len(chosen) == len(stemValues) {
+					for _, z := range common.values() {
+						var cells cellSet
+						for _, petal := range chosen {
+							cells = cells.or(petal.positions[z])
+						}
+						var eliminations []CellDigit = grid.eliminationsSeeing(z, cells, used)
+						if step := alsStep(chosen, append(stemValues[:len(stemValues):len(stemValues)], z), eliminations); step != nil {
+							step.Cells = append([]Cell{stem}, step.Cells...)
+							return step
+						}
+					}
+					return nil
+				}
+				for _, petal := range petals[len(chosen)] {
+					if petal.set.overlaps(used) || common&petal.values == 0 {
+						continue
+					}
+					chosen = append(chosen, petal)
+					var step *Step = blossom(common&petal.values, used.or(petal.set))
+					chosen = chosen[:len(chosen)-1]
+					if step != nil {
+						return step
+					}
+				}
+				return nil
+			}
+
+			var used cellSet
+			used.add(stem)
+			if step := blossom(allDigits&^grid.get(stem), used); step != nil {
+				return step
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+easierThan returns the default techniques with a difficulty below difficulty.
+*/
+func easierThan(difficulty int) []Technique {
+	var ret []Technique
+	for _, technique := range DefaultTechniques() {
+		if technique.Difficulty < difficulty {
+			ret = append(ret, technique)
+		}
+	}
+
+	return ret
+}
+
+func Test_cellSet(t *testing.T) {
+	var set, other cellSet
+	assert.True(t, set.empty())
+	set.add(Cell{Row: 0, Column: 0})
+	set.add(Cell{Row: 8, Column: 8})
+	assert.True(t, set.has(Cell{Row: 8, Column: 8}))
+	assert.False(t, set.has(Cell{Row: 4, Column: 4}))
+
+	other.add(Cell{Row: 8, Column: 8})
+	assert.True(t, other.within(set))
+	assert.False(t, set.within(other))
+	assert.True(t, set.overlaps(other))
+	other = cellSet{}
+	other.add(Cell{Row: 4, Column: 4})
+	assert.False(t, set.overlaps(other))
+
+	var cells []Cell
+	set.or(other).each(func(cell Cell) {
+		cells = append(cells, cell)
+	})
+	assert.Equal(t, []Cell{{Row: 0, Column: 0}, {Row: 4, Column: 4}, {Row: 8, Column: 8}}, cells)
+
+	assert.True(t, peerSets[0].has(Cell{Row: 2, Column: 2}))
+	assert.False(t, peerSets[0].has(Cell{Row: 0, Column: 0}))
+}
+
+/*
+alsGrid returns an open board with r1c1 holding 1 and 2, and r5c1 and r5c2 holding 1 and 3 and 2
+and 3, for the almost locked set tests.
+*/
+func alsGrid() *candidateGrid {
+	var grid *candidateGrid = openCandidateGrid()
+	grid.candidates[0][0] = digitSetOf(0, 1)
+	grid.candidates[4][0] = digitSetOf(0, 2)
+	grid.candidates[4][1] = digitSetOf(1, 2)
+
+	return grid
+}
+
+func Test_candidateGrid_almostLockedSets(t *testing.T) {
+	var grid *candidateGrid = alsGrid()
+	var sets []*almostLockedSet = grid.almostLockedSets()
+
+	/*
+		The three cells on their own, r5c1 with r5c2 once though they share a row and a sub grid, and
+		r1c1 with r5c1 in column 1.
+	*/
+	assert.Len(t, sets, 5)
+	var found bool = false
+	for _, als := range sets {
+		if len(als.cells) == 2 && als.cells[0] == (Cell{Row: 4, Column: 0}) {
+			found = true
+			assert.Equal(t, digitSetOf(0, 1, 2), als.values)
+			assert.True(t, als.positions[2].has(Cell{Row: 4, Column: 1}))
+			assert.False(t, als.positions[0].has(Cell{Row: 4, Column: 1}))
+		}
+	}
+	assert.True(t, found)
+}
+
+func Test_restrictedCommons(t *testing.T) {
+	var grid *candidateGrid = alsGrid()
+	var single, pair *almostLockedSet
+	for _, als := range grid.almostLockedSets() {
+		switch {
+		case len(als.cells) == 1 && als.cells[0] == (Cell{Row: 0, Column: 0}):
+			single = als
+		case len(als.cells) == 2 && als.cells[1] == (Cell{Row: 4, Column: 1}):
+			pair = als
+		}
+	}
+
+	/*
+		Both sets have 1 and 2, but only the 1s all see each other.
+	*/
+	assert.Equal(t, digitSetOf(0), restrictedCommons(single, pair))
+	assert.Equal(t, digitSet(0), restrictedCommons(pair, pair))
+}
+
+func Test_findALSXZ(t *testing.T) {
+	assert.Nil(t, findALSXZ(openCandidateGrid()))
+
+	/*
+		1 cannot be in both r1c1 and r5c1, so 2 is in r1c1 or r5c2 and comes out of the cells that
+		see both.
+	*/
+	var step *Step = findALSXZ(alsGrid())
+	assert.Equal(t, []Cell{{Row: 0, Column: 1}, {Row: 1, Column: 1}, {Row: 2, Column: 1}, {Row: 3, Column: 0}, {Row: 5, Column: 0}}, eliminatedCells(step))
+	assert.Equal(t, []int{2}, eliminatedDigits(step))
+	assert.Equal(t, []int{1, 2}, step.Digits)
+}
+
+func TestLogicalSolver_Solve_als(t *testing.T) {
+	for _, test := range []struct {
+		puzzle     string
+		technique  string
+		techniques []Technique
+	}{
+		{".25.6...39.6..8..2...2...6.......53.89.7...2.......1.9..18..7...4...1...3..5.....", ALSXZ.Name, nil},
+		{"...9...2.83...........4.1..2..3.9..5...75.4..31...8..9.58...............6..1..57.", ALSXYWing.Name, nil},
+		/*
+			The chains and the other almost locked set techniques would get there first.
+		*/
+		{"..4...6...19..........67.9.5.832..1..7.5..3...9......8...7.4..5..5...8.....9.2..3", DeathBlossom.Name,
+			append(easierThan(SimpleColoring.Difficulty), DeathBlossom)},
+	} {
+		game, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+		solution, _, err := CreateSolver().Solve(game)
+		assert.Nil(t, err)
+		var expected string = FormatLine(solution)
+
+		_, steps, err := (&LogicalSolver{Techniques: test.techniques}).Solve(game)
+		assert.True(t, err == nil || err == ErrStuck, test.puzzle)
+		var used bool = false
+		for _, step := range steps {
+			used = used || step.Technique == test.technique
+			for _, elimination := range step.Eliminations {
+				assert.NotEqual(t, expected[elimination.Cell.Row*numColumns+elimination.Cell.Column],
+					byte('0'+elimination.Digit), step.String())
+			}
+		}
+		assert.True(t, used, test.technique)
+	}
+}
@@ -0,0 +1,217 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const A4Width float64 = 595.28
+const A4Height float64 = 841.89
+const DefaultPuzzlesPerPage int = 4
+const DefaultAnswersPerPage int = 9
+
+/*
+BookletPuzzle is one entry of a booklet.  Title and Difficulty are printed above the grid when set.
+Without a Solution the puzzle is left out of the answer key.
+*/
+type BookletPuzzle struct {
+	Title      string
+	Difficulty string
+	Puzzle     *Game
+	Solution   *Game
+}
+
+/*
+BookletOptions controls the layout of WriteBooklet.  Zero values pick the defaults: A4 pages,
+DefaultPuzzlesPerPage puzzles and DefaultAnswersPerPage answers on a page.
+*/
+type BookletOptions struct {
+	Title          string
+	PuzzlesPerPage int
+	AnswersPerPage int
+	PageWidth      float64
+	PageHeight     float64
+}
+
+const bookletMargin float64 = 42
+const bookletHeader float64 = 28
+const bookletFooter float64 = 20
+
+/*
+WriteBooklet writes a printable PDF with the puzzles laid out PuzzlesPerPage to a page, followed by
+an answer key with the solutions.  Givens are printed in bold; in the answer key the digits filled
+in by the solver are printed in grey.
+*/
+func WriteBooklet(w io.Writer, puzzles []*BookletPuzzle, options *BookletOptions) error {
+	if len(puzzles) == 0 {
+		return errors.New("no puzzles on call to WriteBooklet")
+	}
+	if options == nil {
+		options = &BookletOptions{}
+	}
+
+	var settings BookletOptions = *options
+	if settings.PuzzlesPerPage <= 0 {
+		settings.PuzzlesPerPage = DefaultPuzzlesPerPage
+	}
+	if settings.AnswersPerPage <= 0 {
+		settings.AnswersPerPage = DefaultAnswersPerPage
+	}
+	if settings.PageWidth <= 0 || settings.PageHeight <= 0 {
+		settings.PageWidth = A4Width
+		settings.PageHeight = A4Height
+	}
+	if settings.Title == "" {
+		settings.Title = "Sudoku"
+	}
+
+	var answers []int = make([]int, 0, len(puzzles))
+	for i, puzzle := range puzzles {
+		if puzzle == nil || puzzle.Puzzle == nil {
+			return errors.New(fmt.Sprintf("puzzle %d is nil", i+1))
+		}
+		if puzzle.Solution != nil {
+			answers = append(answers, i)
+		}
+	}
+
+	var doc *pdfDocument = newPDFDocument(settings.PageWidth, settings.PageHeight, settings.Title)
+	var pageNumber int = 0
+
+	for start := 0; start < len(puzzles); start += settings.PuzzlesPerPage {
+		pageNumber++
+		var page *pdfPage = doc.addPage()
+		drawBookletFrame(page, &settings, settings.Title, pageNumber)
+		for slot := 0; slot < settings.PuzzlesPerPage && start+slot < len(puzzles); slot++ {
+			var index int = start + slot
+			var puzzle *BookletPuzzle = puzzles[index]
+			x, y, width, height := bookletSlot(&settings, settings.PuzzlesPerPage, slot)
+			drawBookletEntry(page, x, y, width, height, bookletTitle(index, puzzle), puzzle.Difficulty,
+				puzzle.Puzzle, false)
+		}
+	}
+
+	for start := 0; start < len(answers); start += settings.AnswersPerPage {
+		pageNumber++
+		var page *pdfPage = doc.addPage()
+		drawBookletFrame(page, &settings, "Answers", pageNumber)
+		for slot := 0; slot < settings.AnswersPerPage && start+slot < len(answers); slot++ {
+			var index int = answers[start+slot]
+			var puzzle *BookletPuzzle = puzzles[index]
+			var solution *Game = puzzle.Solution
+			if solution.Given == nil {
+				/*
+					Mark the solver's digits against the puzzle's givens so they print in grey.
+				*/
+				solution = &Game{Grid: solution.Grid, Given: puzzle.Puzzle.Givens().givenMask()}
+			}
+			x, y, width, height := bookletSlot(&settings, settings.AnswersPerPage, slot)
+			drawBookletEntry(page, x, y, width, height, bookletTitle(index, puzzle), "", solution, true)
+		}
+	}
+
+	return doc.write(w)
+}
+
+func bookletTitle(index int, puzzle *BookletPuzzle) string {
+	if puzzle.Title == "" {
+		return fmt.Sprintf("Puzzle %d", index+1)
+	}
+
+	return fmt.Sprintf("%d. %s", index+1, puzzle.Title)
+}
+
+/*
+givenMask marks every set cell of gs.
+*/
+func (gs *Game) givenMask() [][]bool {
+	var ret [][]bool = make([][]bool, numRows)
+	for row := 0; row < numRows; row++ {
+		ret[row] = make([]bool, numColumns)
+		for column := 0; column < numColumns; column++ {
+			ret[row][column] = gs.Grid[row][column] != NotSet
+		}
+	}
+
+	return ret
+}
+
+func drawBookletFrame(page *pdfPage, options *BookletOptions, heading string, pageNumber int) {
+	page.centeredText(options.PageWidth/2, bookletMargin+14, pdfBoldFont, 16, 0, heading)
+	page.centeredText(options.PageWidth/2, options.PageHeight-bookletMargin+12, pdfRegularFont, 9, 0.4,
+		fmt.Sprintf("%d", pageNumber))
+}
+
+/*
+bookletSlot returns the area of a page given to entry slot when count entries share the page.  The
+entries are arranged in as square a grid as fits, filled row by row.
+*/
+func bookletSlot(options *BookletOptions, count, slot int) (x, y, width, height float64) {
+	var columns int = int(math.Ceil(math.Sqrt(float64(count))))
+	var rows int = (count + columns - 1) / columns
+	var areaWidth float64 = options.PageWidth - 2*bookletMargin
+	var areaHeight float64 = options.PageHeight - 2*bookletMargin - bookletHeader - bookletFooter
+
+	width = areaWidth / float64(columns)
+	height = areaHeight / float64(rows)
+	x = bookletMargin + float64(slot%columns)*width
+	y = bookletMargin + bookletHeader + float64(slot/columns)*height
+
+	return x, y, width, height
+}
+
+func drawBookletEntry(page *pdfPage, x, y, width, height float64, title, difficulty string, game *Game, answer bool) {
+	var padding float64 = math.Min(width, height) * 0.06
+	var titleSize float64 = math.Max(7, math.Min(12, height/22))
+	var labels float64 = titleSize * 1.6
+	if difficulty != "" {
+		labels += titleSize * 1.2
+	}
+
+	var gridSize float64 = math.Min(width-2*padding, height-2*padding-labels)
+	var gridX float64 = x + (width-gridSize)/2
+	var gridY float64 = y + padding + labels
+
+	page.centeredText(x+width/2, y+padding+titleSize, pdfBoldFont, titleSize, 0, title)
+	if difficulty != "" {
+		page.centeredText(x+width/2, y+padding+titleSize*2.3, pdfRegularFont, titleSize*0.85, 0.3,
+			"Difficulty: "+difficulty)
+	}
+
+	drawBookletGrid(page, gridX, gridY, gridSize, game, answer)
+}
+
+func drawBookletGrid(page *pdfPage, x, y, size float64, game *Game, answer bool) {
+	var cell float64 = size / float64(numColumns)
+	var digitSize float64 = cell * 0.62
+
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var value int = game.Grid[row][column]
+			if value == NotSet {
+				continue
+			}
+
+			var font string = pdfBoldFont
+			var gray float64 = 0
+			if answer && !game.IsGiven(row, column) {
+				font = pdfRegularFont
+				gray = 0.45
+			}
+			page.centeredText(x+(float64(column)+0.5)*cell, y+(float64(row)+0.5)*cell+digitSize*0.36,
+				font, digitSize, gray, fmt.Sprintf("%d", value+1))
+		}
+	}
+
+	for n := 0; n <= numRows; n++ {
+		var width float64 = size / 400
+		if n%subGridRows == 0 {
+			width = size / 110
+		}
+		var offset float64 = float64(n) * cell
+		page.line(x, y+offset, x+size, y+offset, width)
+		page.line(x+offset, y, x+offset, y+size, width)
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteBooklet(t *testing.T) {
+	puzzle, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	solution, err := ParseGame(easyPuzzleSolution)
+	assert.Nil(t, err)
+
+	var puzzles []*BookletPuzzle = make([]*BookletPuzzle, 0)
+	for i := 0; i < 5; i++ {
+		puzzles = append(puzzles, &BookletPuzzle{
+			Title:      "Newspaper (daily)",
+			Difficulty: "easy",
+			Puzzle:     puzzle,
+			Solution:   solution,
+		})
+	}
+	puzzles = append(puzzles, &BookletPuzzle{Puzzle: puzzle})
+
+	var buf bytes.Buffer
+	err = WriteBooklet(&buf, puzzles, &BookletOptions{Title: "Handout", PuzzlesPerPage: 4, AnswersPerPage: 4})
+	assert.Nil(t, err)
+
+	var document string = buf.String()
+	assert.True(t, strings.HasPrefix(document, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(document, "%%EOF\n"))
+	/* two puzzle pages and two answer pages */
+	assert.Equal(t, 4, strings.Count(document, "/Type /Page "))
+	assert.Contains(t, document, "/Count 4")
+	assert.Contains(t, document, `(1. Newspaper \(daily\)) Tj`)
+	assert.Contains(t, document, "(Puzzle 6) Tj")
+	assert.Contains(t, document, "(Difficulty: easy) Tj")
+	assert.Contains(t, document, "(Answers) Tj")
+	assertValidCrossReferences(t, document)
+
+	err = WriteBooklet(&buf, nil, nil)
+	assert.NotNil(t, err)
+
+	err = WriteBooklet(&buf, []*BookletPuzzle{{}}, nil)
+	assert.NotNil(t, err)
+}
+
+func Test_pdfTextWidth(t *testing.T) {
+	assert.InDelta(t, 5.56, pdfTextWidth(pdfRegularFont, 10, "1"), 0.001)
+	assert.InDelta(t, 6.11, pdfTextWidth(pdfBoldFont, 10, "b"), 0.001)
+	assert.Equal(t, pdfTextWidth(pdfRegularFont, 10, "?"), pdfTextWidth(pdfRegularFont, 10, "é"))
+	assert.Equal(t, `a\(b\)\\?`, pdfEscape("a(b)\\é"))
+}
+
+/*
+assertValidCrossReferences checks that every entry of the cross reference table points at the
+start of the object it names and that startxref points at the table.
+*/
+func assertValidCrossReferences(t *testing.T, document string) {
+	var startxref *regexp.Regexp = regexp.MustCompile(`startxref\n(\d+)\n`)
+	var match []string = startxref.FindStringSubmatch(document)
+	assert.NotNil(t, match)
+
+	offset, err := strconv.Atoi(match[1])
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(document[offset:], "xref\n"))
+
+	var entry *regexp.Regexp = regexp.MustCompile(`(\d{10}) 00000 n \n`)
+	var entries [][]string = entry.FindAllStringSubmatch(document[offset:], -1)
+	assert.NotEmpty(t, entries)
+	for i, e := range entries {
+		objectOffset, err := strconv.Atoi(e[1])
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(document[objectOffset:], strconv.Itoa(i+1)+" 0 obj\n"))
+	}
+}
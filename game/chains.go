@@ -0,0 +1,707 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+/*
+The chain techniques come after the Sudoku Explainer ones, roughly in the order players learn them.
+*/
+var (
+	SimpleColoring            = Technique{Name: "Simple Coloring", Difficulty: 56, find: findSimpleColoring}
+	MultiColoring             = Technique{Name: "Multi-Coloring", Difficulty: 58, find: findMultiColoring}
+	XChain                    = Technique{Name: "X-Chain", Difficulty: 60, find: chain(chainOptions{unitLinks: true})}
+	XYChain                   = Technique{Name: "XY-Chain", Difficulty: 62, find: chain(chainOptions{bivalueOnly: true, cellLinks: true})}
+	AlternatingInferenceChain = Technique{Name: "Alternating Inference Chain", Difficulty: 70, find: chain(chainOptions{unitLinks: true, cellLinks: true, groups: true})}
+)
+
+/*
+ChainNode is a digit in one cell, or in a group of cells of one sub grid that also share a row or
+column, standing for the digit being in one of them.
+*/
+type ChainNode struct {
+	Cells []Cell
+	Digit int
+}
+
+func (node ChainNode) String() string {
+	var rows, columns string
+	for _, cell := range node.Cells {
+		if !strings.Contains(rows, fmt.Sprint(cell.Row+1)) {
+			rows += fmt.Sprint(cell.Row + 1)
+		}
+		if !strings.Contains(columns, fmt.Sprint(cell.Column+1)) {
+			columns += fmt.Sprint(cell.Column + 1)
+		}
+	}
+
+	return fmt.Sprintf("%dr%sc%s", node.Digit, rows, columns)
+}
+
+func (node ChainNode) equal(other ChainNode) bool {
+	if node.Digit != other.Digit || len(node.Cells) != len(other.Cells) {
+		return false
+	}
+	for i := range node.Cells {
+		if node.Cells[i] != other.Cells[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+ChainLink joins two nodes of a chain.  A strong link means at least one of them is true, a weak
+link that at most one of them is.
+*/
+type ChainLink struct {
+	From   ChainNode
+	To     ChainNode
+	Strong bool
+}
+
+/*
+chainString writes links in Eureka notation, = for strong links and - for weak ones, starting a new
+run wherever a link does not carry on from the one before.
+*/
+func chainString(links []ChainLink) string {
+	var buf strings.Builder
+	for i, link := range links {
+		if i == 0 || !links[i-1].To.equal(link.From) {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(link.From.String())
+		}
+		if link.Strong {
+			buf.WriteString("=")
+		} else {
+			buf.WriteString("-")
+		}
+		buf.WriteString(link.To.String())
+	}
+
+	return buf.String()
+}
+
+/*
+conjugates returns, for value, the pairs of cells that are the only two places for value in some
+unit, each pair once.
+*/
+func (grid *candidateGrid) conjugates(value int) [][2]Cell {
+	var ret [][2]Cell
+	var seen map[[2]Cell]bool = make(map[[2]Cell]bool)
+	for unit := 0; unit < numUnits; unit++ {
+		var cells []Cell = grid.cellsWith(unit, value)
+		if len(cells) == 2 && !seen[[2]Cell{cells[0], cells[1]}] {
+			seen[[2]Cell{cells[0], cells[1]}] = true
+			ret = append(ret, [2]Cell{cells[0], cells[1]})
+		}
+	}
+
+	return ret
+}
+
+/*
+coloring is a cluster of cells joined by conjugate pairs of one value.  The colors alternate along
+the pairs, so either every cell of color 0 or every cell of color 1 holds the value.
+*/
+type coloring struct {
+	value int
+	color map[Cell]int
+	cells [2][]Cell
+	links []ChainLink
+}
+
+/*
+colorings splits the conjugate pairs of value into clusters, in order of their first cell.
+*/
+func (grid *candidateGrid) colorings(value int) []*coloring {
+	var adjacent map[Cell][]Cell = make(map[Cell][]Cell)
+	var order []Cell
+	for _, pair := range grid.conjugates(value) {
+		for i, cell := range pair {
+			if _, ok := adjacent[cell]; !ok {
+				order = append(order, cell)
+			}
+			adjacent[cell] = append(adjacent[cell], pair[1-i])
+		}
+	}
+
+	var ret []*coloring
+	var colored map[Cell]bool = make(map[Cell]bool)
+	for _, start := range order {
+		if colored[start] {
+			continue
+		}
+		var cluster *coloring = &coloring{value: value, color: map[Cell]int{start: 0}}
+		var queue []Cell = []Cell{start}
+		colored[start] = true
+		for len(queue) > 0 {
+			var cell Cell = queue[0]
+			queue = queue[1:]
+			cluster.cells[cluster.color[cell]] = append(cluster.cells[cluster.color[cell]], cell)
+			for _, next := range adjacent[cell] {
+				if colored[next] {
+					continue
+				}
+				colored[next] = true
+				cluster.color[next] = 1 - cluster.color[cell]
+				cluster.links = append(cluster.links, ChainLink{
+					From:   ChainNode{Cells: []Cell{cell}, Digit: value + 1},
+					To:     ChainNode{Cells: []Cell{next}, Digit: value + 1},
+					Strong: true,
+				})
+				queue = append(queue, next)
+			}
+		}
+		ret = append(ret, cluster)
+	}
+
+	return ret
+}
+
+func (cluster *coloring) allCells() []Cell {
+	return append(append([]Cell{}, cluster.cells[0]...), cluster.cells[1]...)
+}
+
+/*
+seesColor reports whether cell sees a cell of the cluster with color, returning the first it sees.
+*/
+func (cluster *coloring) seesColor(cell Cell, color int) (Cell, bool) {
+	for _, other := range cluster.cells[color] {
+		if sees(cell, other) {
+			return other, true
+		}
+	}
+
+	return Cell{}, false
+}
+
+func weakLink(value int, from, to Cell) ChainLink {
+	return ChainLink{
+		From: ChainNode{Cells: []Cell{from}, Digit: value + 1},
+		To:   ChainNode{Cells: []Cell{to}, Digit: value + 1},
+	}
+}
+
+/*
+findSimpleColoring colors each cluster of conjugate pairs.  Two cells of one color that see each
+other cannot both hold the value, so that color is false everywhere (a color wrap).  Otherwise a
+cell that sees both colors cannot hold it (a color trap).
+*/
+func findSimpleColoring(grid *candidateGrid) *Step {
+	for value := 0; value < numCandidates; value++ {
+		for _, cluster := range grid.colorings(value) {
+			for color := 0; color < 2; color++ {
+				for i, a := range cluster.cells[color] {
+					for _, b := range cluster.cells[color][i+1:] {
+						if sees(a, b) {
+							return &Step{
+								Cells:        cluster.allCells(),
+								Digits:       []int{value + 1},
+								Eliminations: grid.eliminations(value, cluster.cells[color]),
+								Chain:        append(append([]ChainLink{}, cluster.links...), weakLink(value, a, b)),
+							}
+						}
+					}
+				}
+			}
+
+			var eliminations []CellDigit
+			var links []ChainLink = append([]ChainLink{}, cluster.links...)
+			for row := 0; row < numRows; row++ {
+				for column := 0; column < numColumns; column++ {
+					var cell Cell = Cell{Row: row, Column: column}
+					if _, ok := cluster.color[cell]; ok || !grid.get(cell).has(value) {
+						continue
+					}
+					a, seesA := cluster.seesColor(cell, 0)
+					b, seesB := cluster.seesColor(cell, 1)
+					if seesA && seesB {
+						eliminations = append(eliminations, CellDigit{Cell: cell, Digit: value + 1})
+						links = append(links, weakLink(value, a, cell), weakLink(value, b, cell))
+					}
+				}
+			}
+			if len(eliminations) > 0 {
+				return &Step{Cells: cluster.allCells(), Digits: []int{value + 1}, Eliminations: eliminations, Chain: links}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+findMultiColoring compares two clusters of one value.  When a color of the first sees a color of
+the second, the two cannot both be true, so one of their opposites is: a cell that sees both
+opposites cannot hold the value.  When a color sees both colors of the other cluster it is false.
+*/
+func findMultiColoring(grid *candidateGrid) *Step {
+	for value := 0; value < numCandidates; value++ {
+		var clusters []*coloring = grid.colorings(value)
+		for i, first := range clusters {
+			for j, second := range clusters {
+				if i == j {
+					continue
+				}
+				if step := multiColoring(grid, first, second); step != nil {
+					return step
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func multiColoring(grid *candidateGrid, first, second *coloring) *Step {
+	var value int = first.value
+	var cells []Cell = append(first.allCells(), second.allCells()...)
+	for color := 0; color < 2; color++ {
+		var links [2][]ChainLink
+		var seen [2]bool
+		for other := 0; other < 2; other++ {
+			for _, a := range first.cells[color] {
+				if b, ok := second.seesColor(a, other); ok {
+					seen[other] = true
+					links[other] = []ChainLink{weakLink(value, a, b)}
+					break
+				}
+			}
+		}
+
+		var chain []ChainLink = append(append([]ChainLink{}, first.links...), second.links...)
+		if seen[0] && seen[1] {
+			var eliminations []CellDigit = grid.eliminations(value, first.cells[color])
+			if len(eliminations) > 0 {
+				return &Step{Cells: cells, Digits: []int{value + 1}, Eliminations: eliminations,
+					Chain: append(append(chain, links[0]...), links[1]...)}
+			}
+		}
+
+		for other := 0; other < 2; other++ {
+			if !seen[other] {
+				continue
+			}
+			var eliminations []CellDigit
+			for row := 0; row < numRows; row++ {
+				for column := 0; column < numColumns; column++ {
+					var cell Cell = Cell{Row: row, Column: column}
+					if !grid.get(cell).has(value) {
+						continue
+					}
+					_, seesFirst := first.seesColor(cell, 1-color)
+					_, seesSecond := second.seesColor(cell, 1-other)
+					if seesFirst && seesSecond {
+						eliminations = append(eliminations, CellDigit{Cell: cell, Digit: value + 1})
+					}
+				}
+			}
+			if len(eliminations) > 0 {
+				return &Step{Cells: cells, Digits: []int{value + 1}, Eliminations: eliminations,
+					Chain: append(chain, links[other]...)}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+chainOptions chooses the links a chain may use: strong links between the only two places for a
+value in a unit, strong links between the two candidates of a cell and weak links between the
+candidates of one cell, and whether nodes may be groups.  bivalueOnly keeps to cells with two
+candidates.
+*/
+type chainOptions struct {
+	unitLinks   bool
+	cellLinks   bool
+	groups      bool
+	bivalueOnly bool
+}
+
+/*
+candidateSet holds candidates of single cells, bit (row * 9 + column) * 9 + value.
+*/
+type candidateSet [(numRows*numColumns*numCandidates + 63) / 64]uint64
+
+func (set *candidateSet) add(cell Cell, value int) {
+	var bit int = (cell.Row*numColumns+cell.Column)*numCandidates + value
+	set[bit/64] |= 1 << (bit % 64)
+}
+
+func (set candidateSet) and(other candidateSet) candidateSet {
+	for i := range set {
+		set[i] &= other[i]
+	}
+
+	return set
+}
+
+func (set candidateSet) each(visit func(cell Cell, value int)) {
+	for i, word := range set {
+		for ; word != 0; word &= word - 1 {
+			var bit int = i*64 + bits.TrailingZeros64(word)
+			var index int = bit / numCandidates
+			visit(Cell{Row: index / numColumns, Column: index % numColumns}, bit%numCandidates)
+		}
+	}
+}
+
+type chainGraphNode struct {
+	value int
+	cells []Cell
+}
+
+/*
+chainGraph holds the nodes of a grid and the links between them.  targets holds, for each node, the
+candidates that cannot be true when the node is.
+*/
+type chainGraph struct {
+	nodes   []chainGraphNode
+	strong  [][]int
+	weak    [][]int
+	targets []candidateSet
+}
+
+func (graph *chainGraph) exported(node int) ChainNode {
+	return ChainNode{Cells: graph.nodes[node].cells, Digit: graph.nodes[node].value + 1}
+}
+
+func (graph *chainGraph) addNode(value int, cells []Cell) int {
+	graph.nodes = append(graph.nodes, chainGraphNode{value: value, cells: cells})
+	graph.strong = append(graph.strong, nil)
+	graph.weak = append(graph.weak, nil)
+	graph.targets = append(graph.targets, candidateSet{})
+	return len(graph.nodes) - 1
+}
+
+func (graph *chainGraph) link(a, b int, strong bool) {
+	if strong {
+		graph.strong[a] = append(graph.strong[a], b)
+		graph.strong[b] = append(graph.strong[b], a)
+		return
+	}
+	graph.weak[a] = append(graph.weak[a], b)
+	graph.weak[b] = append(graph.weak[b], a)
+}
+
+/*
+newChainGraph builds the graph of grid for options.  Nodes are the candidates of single cells,
+then, with groups, every two or three cells of a sub grid and a line that share a value.
+*/
+func newChainGraph(grid *candidateGrid, options chainOptions) *chainGraph {
+	var graph *chainGraph = &chainGraph{}
+	var single map[CellDigit]int = make(map[CellDigit]int)
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var cell Cell = Cell{Row: row, Column: column}
+			if options.bivalueOnly && grid.get(cell).count() != 2 {
+				continue
+			}
+			for _, value := range grid.get(cell).values() {
+				single[CellDigit{Cell: cell, Digit: value}] = graph.addNode(value, []Cell{cell})
+			}
+		}
+	}
+
+	var groups map[string]int = make(map[string]int)
+	var nodeOf = func(value int, cells []Cell) (int, bool) {
+		if len(cells) == 1 {
+			node, ok := single[CellDigit{Cell: cells[0], Digit: value}]
+			return node, ok
+		}
+		if !options.groups {
+			return 0, false
+		}
+		var key string = fmt.Sprint(value, cells)
+		if node, ok := groups[key]; ok {
+			return node, true
+		}
+		groups[key] = graph.addNode(value, cells)
+		return groups[key], true
+	}
+
+	if options.groups {
+		for value := 0; value < numCandidates; value++ {
+			for unit := 0; unit < numRows+numColumns; unit++ {
+				for _, part := range splitCells(grid.cellsWith(unit, value), func(cell Cell) int {
+					return subGridIndex(cell.Row, cell.Column)
+				}) {
+					nodeOf(value, part)
+				}
+			}
+		}
+	}
+
+	if options.unitLinks {
+		for value := 0; value < numCandidates; value++ {
+			for unit := 0; unit < numUnits; unit++ {
+				var cells []Cell = grid.cellsWith(unit, value)
+				for _, key := range unitSplits(unit) {
+					var parts [][]Cell = splitCells(cells, key)
+					if len(parts) != 2 {
+						continue
+					}
+					a, okA := nodeOf(value, parts[0])
+					b, okB := nodeOf(value, parts[1])
+					if okA && okB {
+						graph.link(a, b, true)
+					}
+				}
+			}
+		}
+	}
+
+	for i := range graph.nodes {
+		for j := i + 1; j < len(graph.nodes); j++ {
+			var a, b chainGraphNode = graph.nodes[i], graph.nodes[j]
+			var sameCell bool = len(a.cells) == 1 && len(b.cells) == 1 && a.cells[0] == b.cells[0]
+			if sameCell && options.cellLinks {
+				if grid.get(a.cells[0]).count() == 2 {
+					graph.link(i, j, true)
+				}
+				graph.link(i, j, false)
+			}
+			if !sameCell && a.value == b.value && allSee(a.cells, b.cells) {
+				graph.link(i, j, false)
+			}
+		}
+	}
+	if options.bivalueOnly {
+		/*
+			An XY-Chain only moves between cells on weak links of one value.
+		*/
+		for i, node := range graph.nodes {
+			var weak []int
+			for _, j := range graph.weak[i] {
+				if graph.nodes[j].value == node.value {
+					weak = append(weak, j)
+				}
+			}
+			graph.weak[i] = weak
+		}
+	}
+
+	for i, node := range graph.nodes {
+		for row := 0; row < numRows; row++ {
+			for column := 0; column < numColumns; column++ {
+				var cell Cell = Cell{Row: row, Column: column}
+				for _, value := range grid.get(cell).values() {
+					var sameCell bool = len(node.cells) == 1 && node.cells[0] == cell
+					if (sameCell && value != node.value) || (value == node.value && allSee(node.cells, []Cell{cell})) {
+						graph.targets[i].add(cell, value)
+					}
+				}
+			}
+		}
+	}
+
+	return graph
+}
+
+/*
+unitSplits returns the ways cells of unit can be split into two nodes: by sub grid, and into single
+cells for two cells of one sub grid, for a row or column, and by row and by column for a sub grid.
+*/
+func unitSplits(unit int) []func(cell Cell) int {
+	if unit < numRows+numColumns {
+		return []func(cell Cell) int{
+			func(cell Cell) int { return subGridIndex(cell.Row, cell.Column) },
+			func(cell Cell) int { return cell.Row*numColumns + cell.Column },
+		}
+	}
+
+	return []func(cell Cell) int{
+		func(cell Cell) int { return cell.Row },
+		func(cell Cell) int { return cell.Column },
+	}
+}
+
+/*
+splitCells groups cells by key, keeping their order.
+*/
+func splitCells(cells []Cell, key func(cell Cell) int) [][]Cell {
+	var ret [][]Cell
+	var index map[int]int = make(map[int]int)
+	for _, cell := range cells {
+		i, ok := index[key(cell)]
+		if !ok {
+			i = len(ret)
+			index[key(cell)] = i
+			ret = append(ret, nil)
+		}
+		ret[i] = append(ret[i], cell)
+	}
+
+	return ret
+}
+
+/*
+allSee reports whether every cell of a sees every cell of b.
+*/
+func allSee(a, b []Cell) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if !sees(x, y) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+/*
+chain searches for alternating inference chains: starting from a node taken to be false, a strong
+link makes the next node true and a weak link from a true node makes the next false.  Reaching a
+node that is true shows one of the two ends is, so every candidate seen by both ends goes.  When
+the chain comes back to its start the start is true.  When the end and the start are weakly linked
+the chain is a continuous loop, where every weak link holds both ways, and a candidate seen by
+both nodes of any weak link of the loop goes.  The search is breadth first, so the shortest chain
+is found.
+*/
+func chain(options chainOptions) func(grid *candidateGrid) *Step {
+	return func(grid *candidateGrid) *Step {
+		var graph *chainGraph = newChainGraph(grid, options)
+		for start := range graph.nodes {
+			if step := graph.search(start); step != nil {
+				return step
+			}
+		}
+
+		return nil
+	}
+}
+
+/*
+chainState is a node of a chain with whether it has been shown true, on, or false, off.
+*/
+type chainState struct {
+	node int
+	on   bool
+}
+
+/*
+search walks the chains from start in breadth first order.
+*/
+func (graph *chainGraph) search(start int) *Step {
+	var parent map[chainState]chainState = make(map[chainState]chainState)
+	var queue []chainState = []chainState{{node: start}}
+	parent[queue[0]] = queue[0]
+
+	var path = func(end chainState) []chainState {
+		var ret []chainState = []chainState{end}
+		for current := end; current != parent[current]; current = parent[current] {
+			ret = append([]chainState{parent[current]}, ret...)
+		}
+		return ret
+	}
+
+	for len(queue) > 0 {
+		var current chainState = queue[0]
+		queue = queue[1:]
+
+		if current.on {
+			if step := graph.conclude(path(current)); step != nil {
+				return step
+			}
+		}
+
+		var next []int = graph.weak[current.node]
+		if !current.on {
+			next = graph.strong[current.node]
+		}
+		for _, node := range next {
+			var following chainState = chainState{node: node, on: !current.on}
+			if _, ok := parent[following]; ok {
+				continue
+			}
+			parent[following] = current
+			queue = append(queue, following)
+		}
+	}
+
+	return nil
+}
+
+/*
+conclude returns the step proved by a chain from its start, taken to be false, to an end shown to
+be true, nil when it proves nothing.  Nodes alternate off and on along the chain.
+*/
+func (graph *chainGraph) conclude(states []chainState) *Step {
+	var start, end int = states[0].node, states[len(states)-1].node
+	var links []ChainLink = make([]ChainLink, 0, len(states))
+	var cells []Cell
+	var nodes map[int]bool = make(map[int]bool)
+	for i, current := range states {
+		nodes[current.node] = true
+		for _, cell := range graph.nodes[current.node].cells {
+			if len(cellsExcept([]Cell{cell}, cells)) > 0 {
+				cells = append(cells, cell)
+			}
+		}
+		if i > 0 {
+			links = append(links, ChainLink{
+				From:   graph.exported(states[i-1].node),
+				To:     graph.exported(current.node),
+				Strong: current.on,
+			})
+		}
+	}
+	var step *Step = &Step{Cells: cells, Chain: links}
+
+	if start == end {
+		if len(graph.nodes[start].cells) != 1 {
+			return nil
+		}
+		step.Digits = []int{graph.nodes[start].value + 1}
+		step.Placements = []CellDigit{{Cell: graph.nodes[start].cells[0], Digit: graph.nodes[start].value + 1}}
+		return step
+	}
+
+	var eliminated candidateSet = graph.targets[start].and(graph.targets[end])
+	if len(nodes) == len(states) && graph.weaklyLinked(end, start) {
+		step.Chain = append(step.Chain, ChainLink{From: graph.exported(end), To: graph.exported(start)})
+		for i := 1; i < len(states); i += 2 {
+			var both candidateSet = graph.targets[states[i].node].and(graph.targets[states[(i+1)%len(states)].node])
+			for j := range eliminated {
+				eliminated[j] |= both[j]
+			}
+		}
+	}
+
+	var values digitSet = 0
+	eliminated.each(func(cell Cell, value int) {
+		for node := range nodes {
+			if len(graph.nodes[node].cells) == 1 && graph.nodes[node].cells[0] == cell && graph.nodes[node].value == value {
+				return
+			}
+		}
+		step.Eliminations = append(step.Eliminations, CellDigit{Cell: cell, Digit: value + 1})
+	})
+	if len(step.Eliminations) == 0 {
+		return nil
+	}
+	for _, state := range states {
+		values = values.add(graph.nodes[state.node].value)
+	}
+	step.Digits = digits(values)
+
+	return step
+}
+
+func (graph *chainGraph) weaklyLinked(a, b int) bool {
+	for _, node := range graph.weak[a] {
+		if node == b {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainNode_String(t *testing.T) {
+	assert.Equal(t, "4r5c8", ChainNode{Cells: []Cell{{Row: 4, Column: 7}}, Digit: 4}.String())
+	assert.Equal(t, "1r46c2", ChainNode{Cells: []Cell{{Row: 3, Column: 1}, {Row: 5, Column: 1}}, Digit: 1}.String())
+	assert.Equal(t, "7r1c123", ChainNode{Cells: []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}, {Row: 0, Column: 2}}, Digit: 7}.String())
+}
+
+func Test_chainString(t *testing.T) {
+	var a, b, c, d ChainNode = ChainNode{Cells: []Cell{{Row: 0, Column: 0}}, Digit: 8},
+		ChainNode{Cells: []Cell{{Row: 0, Column: 8}}, Digit: 8},
+		ChainNode{Cells: []Cell{{Row: 6, Column: 8}}, Digit: 8},
+		ChainNode{Cells: []Cell{{Row: 6, Column: 0}}, Digit: 8}
+	assert.Equal(t, "8r1c1=8r1c9-8r7c9=8r7c1", chainString([]ChainLink{
+		{From: a, To: b, Strong: true},
+		{From: b, To: c},
+		{From: c, To: d, Strong: true},
+	}))
+	assert.Equal(t, "8r1c1=8r1c9, 8r7c9=8r7c1", chainString([]ChainLink{
+		{From: a, To: b, Strong: true},
+		{From: c, To: d, Strong: true},
+	}))
+}
+
+func Test_findSimpleColoring(t *testing.T) {
+	/*
+		1 is confined to columns 0 and 4 in row 4 and to rows 0 and 4 in columns 0 and 4, so the
+		corners of the rectangle alternate colors.  r1c9 sees r1c1 and r1c5, one of each color.
+	*/
+	var grid *candidateGrid = openCandidateGrid()
+	confine(grid, 0, 0, true, 0, 4, 8)
+	confine(grid, 0, 4, true, 0, 4)
+	confine(grid, 0, 0, false, 0, 4)
+	confine(grid, 0, 4, false, 0, 4)
+
+	var step *Step = findSimpleColoring(grid)
+	assert.Equal(t, []int{1}, step.Digits)
+	assert.Len(t, step.Cells, 4)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 0, Column: 8}, Digit: 1}}, step.Eliminations)
+	assert.Len(t, step.Chain, 3+2)
+
+	/*
+		r1c1=r1c5=r5c5=r5c2=r2c2 alternates colors, leaving r1c1 and r2c2 with the same color in
+		one sub grid: that color is false.
+	*/
+	grid = openCandidateGrid()
+	confine(grid, 0, 0, true, 0, 4)
+	confine(grid, 0, 4, false, 0, 4)
+	confine(grid, 0, 4, true, 1, 4)
+	confine(grid, 0, 1, false, 1, 4)
+	step = findSimpleColoring(grid)
+	assert.False(t, step.Chain[len(step.Chain)-1].Strong)
+	assert.Equal(t, []CellDigit{
+		{Cell: Cell{Row: 0, Column: 0}, Digit: 1},
+		{Cell: Cell{Row: 4, Column: 4}, Digit: 1},
+		{Cell: Cell{Row: 1, Column: 1}, Digit: 1},
+	}, step.Eliminations)
+}
+
+func TestLogicalSolver_Solve_chains(t *testing.T) {
+	for _, test := range []struct {
+		puzzle    string
+		technique string
+	}{
+		{"..4...6...19..........67.9.5.832..1..7.5..3...9......8...7.4..5..5...8.....9.2..3", SimpleColoring.Name},
+		{"...4....7..89.....52.8..46.....4..3527...6..........9..67....2889....5.......7..4", MultiColoring.Name},
+		{".2....7......8.12....7...46...95......3..6.5.5.........154...9.3.9...6..7..1....3", XChain.Name},
+		{"..4...6...19..........67.9.5.832..1..7.5..3...9......8...7.4..5..5...8.....9.2..3", XYChain.Name},
+		{"...4....7..89.....52.8..46.....4..3527...6..........9..67....2889....5.......7..4", AlternatingInferenceChain.Name},
+	} {
+		game, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+		solution, _, err := CreateSolver().Solve(game)
+		assert.Nil(t, err)
+		var expected string = FormatLine(solution)
+
+		/*
+			The uniqueness techniques would solve some of these before the chains get a turn.
+		*/
+		solved, steps, err := (&LogicalSolver{SkipUniqueness: true}).Solve(game)
+		assert.Nil(t, err, test.puzzle)
+		assert.Equal(t, expected, FormatLine(solved))
+
+		var used bool = false
+		for _, step := range steps {
+			if step.Technique != test.technique {
+				continue
+			}
+			used = true
+			assert.NotEmpty(t, step.Chain, step.String())
+			for _, elimination := range step.Eliminations {
+				assert.NotEqual(t, expected[elimination.Cell.Row*numColumns+elimination.Cell.Column],
+					byte('0'+elimination.Digit), step.String())
+			}
+		}
+		assert.True(t, used, test.technique)
+	}
+
+	/*
+		Without the chains the same puzzles get stuck.
+	*/
+	game, err := ParseGame(".2....7......8.12....7...46...95......3..6.5.5.........154...9.3.9...6..7..1....3")
+	assert.Nil(t, err)
+	_, _, err = (&LogicalSolver{Techniques: easierThan(SimpleColoring.Difficulty)}).Solve(game)
+	assert.ErrorIs(t, err, ErrStuck)
+}
+
+func Test_chain_loop(t *testing.T) {
+	/*
+		Cells with 12, 23 and 13 in one row and sub grid close a continuous XY loop.  Either way round
+		they hold 1, 2 and 3, so those come out of the rest of the row and the sub grid.
+	*/
+	var grid *candidateGrid = openCandidateGrid()
+	grid.candidates[0][0] = digitSetOf(0, 1)
+	grid.candidates[0][1] = digitSetOf(1, 2)
+	grid.candidates[0][2] = digitSetOf(0, 2)
+
+	var step *Step = chain(chainOptions{bivalueOnly: true, cellLinks: true})(grid)
+	assert.NotNil(t, step)
+	assert.False(t, step.Chain[len(step.Chain)-1].Strong)
+	assert.True(t, step.Chain[len(step.Chain)-1].To.equal(step.Chain[0].From))
+	for _, elimination := range step.Eliminations {
+		assert.NotContains(t, []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}, {Row: 0, Column: 2}}, elimination.Cell)
+		assert.True(t, elimination.Cell.Row == 0 || subGridIndex(elimination.Cell.Row, elimination.Cell.Column) == 0)
+	}
+	assert.Len(t, step.Eliminations, 3*(6+6))
+}
+
+func Test_chain_lineInSubGrid(t *testing.T) {
+	/*
+		The only 1s of row 1 are r1c1 and r1c2, in one sub grid.  They are a strong link, and with the
+		weak link of the sub grid back they close a loop that takes 1 out of the rest of the sub grid.
+	*/
+	var grid *candidateGrid = openCandidateGrid()
+	confine(grid, 0, 0, true, 0, 1)
+
+	var graph *chainGraph = newChainGraph(grid, chainOptions{unitLinks: true})
+	var first, second int = -1, -1
+	for i, node := range graph.nodes {
+		if node.value == 0 && node.cells[0] == (Cell{Row: 0, Column: 0}) {
+			first = i
+		}
+		if node.value == 0 && node.cells[0] == (Cell{Row: 0, Column: 1}) {
+			second = i
+		}
+	}
+	assert.Contains(t, graph.strong[first], second)
+
+	var step *Step = XChain.find(grid)
+	assert.NotNil(t, step)
+	assert.True(t, step.Chain[0].Strong)
+	assert.Equal(t, []Cell{{Row: 1, Column: 0}, {Row: 1, Column: 1}, {Row: 1, Column: 2}, {Row: 2, Column: 0}, {Row: 2, Column: 1}, {Row: 2, Column: 2}},
+		eliminatedCells(step))
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+UnitKind names the three kinds of unit a digit may appear in only once.
+*/
+type UnitKind int
+
+const (
+	RowUnit UnitKind = iota
+	ColumnUnit
+	SubGridUnit
+)
+
+func (kind UnitKind) String() string {
+	switch kind {
+	case RowUnit:
+		return "row"
+	case ColumnUnit:
+		return "column"
+	case SubGridUnit:
+		return "sub grid"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+ConflictError reports a digit that appears more than once in a unit.  Index is the row, column or
+sub grid, counted from 0 like Cell, and Cells holds every cell of the unit with the digit, in
+reading order for rows and columns and left to right, top to bottom within a sub grid.
+*/
+type ConflictError struct {
+	Unit  UnitKind
+	Index int
+	Digit int
+	Cells []Cell
+}
+
+func (conflict ConflictError) Error() string {
+	return fmt.Sprintf("%s %d has %d more than once, at %v", conflict.Unit, conflict.Index, conflict.Digit, conflict.Cells)
+}
+
+/*
+ValidateGame returns every conflict on the board of game: each digit that appears more than once in
+a row, column or sub grid, rows first, then columns, then sub grids.  A cell in conflict in more
+than one unit is reported in each of them.  Values out of range are ignored.  A board without
+conflicts, or a nil game, gives none.
+*/
+func ValidateGame(game *Game) []ConflictError {
+	if game == nil {
+		return nil
+	}
+
+	var ret []ConflictError
+	for unit := 0; unit < numUnits; unit++ {
+		ret = append(ret, unitConflicts(game.Grid, unit)...)
+	}
+
+	return ret
+}
+
+/*
+unitConflicts returns the conflicts in units[unit], in order of digit.
+*/
+func unitConflicts(grid [][]int, unit int) []ConflictError {
+	var cells [numCandidates][]Cell
+	for _, cell := range units[unit] {
+		if cell.Row >= len(grid) || cell.Column >= len(grid[cell.Row]) {
+			continue
+		}
+		var value int = grid[cell.Row][cell.Column]
+		if value < 0 || value >= numCandidates {
+			continue
+		}
+		cells[value] = append(cells[value], cell)
+	}
+
+	var kind UnitKind = RowUnit
+	var index int = unit
+	switch {
+	case unit >= numRows+numColumns:
+		kind, index = SubGridUnit, unit-numRows-numColumns
+	case unit >= numRows:
+		kind, index = ColumnUnit, unit-numRows
+	}
+
+	var ret []ConflictError
+	for value, list := range cells {
+		if len(list) > 1 {
+			ret = append(ret, ConflictError{Unit: kind, Index: index, Digit: value + 1, Cells: list})
+		}
+	}
+
+	return ret
+}
+
+/*
+conflictsError joins conflicts into one error, nil when there are none.
+*/
+func conflictsError(conflicts []ConflictError) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	var errs []error = make([]error, len(conflicts))
+	for i, conflict := range conflicts {
+		errs[i] = conflict
+	}
+
+	return errors.Join(errs...)
+}
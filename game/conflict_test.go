@@ -0,0 +1,54 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateGame(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	assert.Empty(t, ValidateGame(game))
+	assert.Empty(t, ValidateGame(nil))
+
+	/*
+		A second 5 in row 0 clashes with the given 5 in row 0, column 0 in the row and the sub grid,
+		and a 7 in row 6, column 4 clashes with the given 7 in row 0, column 4.
+	*/
+	game.Grid[0][2] = 4
+	game.Grid[6][4] = 6
+	var conflicts []ConflictError = ValidateGame(game)
+	assert.Equal(t, []ConflictError{
+		{Unit: RowUnit, Index: 0, Digit: 5, Cells: []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 2}}},
+		{Unit: ColumnUnit, Index: 4, Digit: 7, Cells: []Cell{{Row: 0, Column: 4}, {Row: 6, Column: 4}}},
+		{Unit: SubGridUnit, Index: 0, Digit: 5, Cells: []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 2}}},
+	}, conflicts)
+	assert.Equal(t, "row 0 has 5 more than once, at [{0 0} {0 2}]", conflicts[0].Error())
+	assert.Equal(t, "sub grid", conflicts[2].Unit.String())
+}
+
+func Test_ValidateGame_errorsAs(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	game.Grid[0][2] = 4
+
+	_, _, err = CreateSolver().Solve(game)
+	var conflict ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, RowUnit, conflict.Unit)
+	assert.Equal(t, 5, conflict.Digit)
+	assert.Len(t, conflict.Cells, 2)
+
+	gs, err := createGame(NewGame())
+	assert.Nil(t, err)
+	gs.Grid[4][4] = 2
+	gs.Grid[4][5] = 2
+	gs.Grid[5][4] = 2
+	err = validateGameState(gs)
+	assert.True(t, errors.As(err, &conflict))
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+	assert.NotNil(t, validateSubGrid(gs, 4))
+	assert.NotNil(t, validateSubGrid(gs, numSubSquares))
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"math/bits"
+)
+
+/*
+digitSet is a set of the values 0-8 stored as a 9 bit mask, bit n standing for value n.
+*/
+type digitSet uint16
+
+const allDigits digitSet = 1<<numCandidates - 1
+
+func digitSetOf(values ...int) digitSet {
+	var ret digitSet = 0
+	for _, value := range values {
+		ret |= 1 << value
+	}
+
+	return ret
+}
+
+func (s digitSet) has(value int) bool {
+	return s&(1<<value) != 0
+}
+
+func (s digitSet) add(value int) digitSet {
+	return s | 1<<value
+}
+
+func (s digitSet) remove(value int) digitSet {
+	return s &^ (1 << value)
+}
+
+func (s digitSet) count() int {
+	return bits.OnesCount16(uint16(s))
+}
+
+/*
+first returns the smallest value in the set, or NotSet for the empty set.
+*/
+func (s digitSet) first() int {
+	if s == 0 {
+		return NotSet
+	}
+
+	return bits.TrailingZeros16(uint16(s))
+}
+
+func (s digitSet) values() []int {
+	var ret []int = make([]int, 0, s.count())
+	for rest := s; rest != 0; rest &= rest - 1 {
+		ret = append(ret, bits.TrailingZeros16(uint16(rest)))
+	}
+
+	return ret
+}
+
+func subGridIndex(row, column int) int {
+	return (row/subGridRows)*subGridRows + column/subGridColumns
+}
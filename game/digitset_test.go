@@ -0,0 +1,77 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_digitSet(t *testing.T) {
+	var set digitSet = digitSetOf(0, 4, 8)
+	assert.Equal(t, 3, set.count())
+	assert.True(t, set.has(4))
+	assert.False(t, set.has(5))
+	assert.Equal(t, []int{0, 4, 8}, set.values())
+	assert.Equal(t, 0, set.first())
+
+	set = set.remove(0).add(2)
+	assert.Equal(t, []int{2, 4, 8}, set.values())
+	assert.Equal(t, 2, set.first())
+	assert.Equal(t, NotSet, digitSet(0).first())
+	assert.Equal(t, numCandidates, allDigits.count())
+}
+
+func Test_subGridIndex(t *testing.T) {
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var subSquare int = subGridIndex(row, column)
+			baseRow, _ := computeBaseRow(subSquare)
+			baseColumn, _ := computeBaseColumn(subSquare)
+			minRow, _ := snapToGrid(row)
+			minColumn, _ := snapToGrid(column)
+			assert.Equal(t, baseRow, minRow)
+			assert.Equal(t, baseColumn, minColumn)
+		}
+	}
+}
+
+func Test_gameState_cellCandidates(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	gs, err := createGame(game)
+	assert.Nil(t, err)
+
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			assert.Equal(t, game.Candidates(row, column), gs.cellCandidates(row, column).values())
+		}
+	}
+
+	var c *candidate = &candidate{row: 0, column: 2, value: 3}
+	assert.True(t, gs.canPlace(c))
+	gs.addCandidate(c)
+	assert.False(t, gs.canPlace(c))
+	assert.Equal(t, []int{1, 5}, gs.cellCandidates(0, 3).values())
+	gs.removeCandidate(c)
+	assert.True(t, gs.canPlace(c))
+
+	/* a duplicate keeps the value in the masks until both copies are gone */
+	var first *candidate = &candidate{row: 4, column: 4, value: 4}
+	var second *candidate = &candidate{row: 4, column: 6, value: 4}
+	gs.addCandidate(first)
+	gs.addCandidate(second)
+	gs.removeCandidate(first)
+	assert.False(t, gs.cellCandidates(4, 4).has(4))
+	gs.removeCandidate(second)
+	assert.True(t, gs.cellCandidates(4, 4).has(4))
+
+	/* overwriting a cell releases its old value */
+	gs.addCandidate(first)
+	gs.addCandidate(&candidate{row: 4, column: 4, value: 6})
+	assert.True(t, gs.cellCandidates(4, 6).has(4))
+	assert.False(t, gs.cellCandidates(4, 6).has(6))
+
+	_, err = createGame(&Game{Grid: [][]int{{9}}})
+	assert.NotNil(t, err)
+}
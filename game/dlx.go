@@ -0,0 +1,319 @@
+package game
+
+import (
+	"context"
+)
+
+/*
+DLXEngine solves a game as an exact cover problem with Knuth's Algorithm X on dancing links.
+
+Every placement of a value in a cell is a row of the matrix, and every rule of the puzzle is a
+column that exactly one chosen row has to cover:
+
+	  0 - 80   cell (row, column) holds a value
+	 81 - 161  row holds value
+	162 - 242  column holds value
+	243 - 323  sub grid holds value
+
+A placement covers one column of each group.  The columns and rows already settled by the set cells
+of the game are left out of the matrix.  The search always branches on the column with the fewest
+rows left, which solves the hardest published puzzles in a few thousand iterations.
+
+Iterations and CandidatesEvaluated count the rows tried and BackTracks the rows taken back.  A
+node is expanded each time a column is chosen, its rows being the branches.
+*/
+type DLXEngine struct {
+}
+
+const dlxCellColumns int = 0
+const dlxRowColumns int = numRows * numColumns
+const dlxColumnColumns int = 2 * numRows * numColumns
+const dlxSubGridColumns int = 3 * numRows * numColumns
+const dlxColumns int = 4 * numRows * numColumns
+
+/*
+dlxMatrix holds the links in parallel slices.  Node 0 is the root, nodes 1 through dlxColumns are
+the column headers and the nodes after them belong to the rows.
+*/
+type dlxMatrix struct {
+	left      []int
+	right     []int
+	up        []int
+	down      []int
+	header    []int
+	size      []int
+	placement []*candidate
+}
+
+func newDLXMatrix(gs *gameState) *dlxMatrix {
+	var nodes int = 1 + dlxColumns + 4*numRows*numColumns*numCandidates
+	var m *dlxMatrix = &dlxMatrix{
+		left:      make([]int, 1+dlxColumns, nodes),
+		right:     make([]int, 1+dlxColumns, nodes),
+		up:        make([]int, 1+dlxColumns, nodes),
+		down:      make([]int, 1+dlxColumns, nodes),
+		header:    make([]int, 1+dlxColumns, nodes),
+		size:      make([]int, 1+dlxColumns),
+		placement: make([]*candidate, 1+dlxColumns, nodes),
+	}
+
+	for node := 0; node <= dlxColumns; node++ {
+		m.up[node] = node
+		m.down[node] = node
+		m.header[node] = node
+	}
+
+	/*
+		Only the columns still to be covered go into the header list.
+	*/
+	var open []int = make([]int, 0, dlxColumns)
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			if gs.Grid[row][column] == NotSet {
+				open = append(open, dlxCellColumns+row*numColumns+column)
+			}
+		}
+	}
+	for unit := 0; unit < numRows; unit++ {
+		for value := 0; value < numCandidates; value++ {
+			if !gs.rowMasks[unit].has(value) {
+				open = append(open, dlxRowColumns+unit*numCandidates+value)
+			}
+			if !gs.columnMasks[unit].has(value) {
+				open = append(open, dlxColumnColumns+unit*numCandidates+value)
+			}
+			if !gs.subGridMasks[unit].has(value) {
+				open = append(open, dlxSubGridColumns+unit*numCandidates+value)
+			}
+		}
+	}
+
+	var last int = 0
+	for _, index := range open {
+		m.right[last] = index + 1
+		m.left[index+1] = last
+		last = index + 1
+	}
+	m.right[last] = 0
+	m.left[0] = last
+
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			for _, value := range gs.cellCandidates(row, column).values() {
+				m.addRow(&candidate{value: value, row: row, column: column})
+			}
+		}
+	}
+
+	return m
+}
+
+func dlxColumnsOf(c *candidate) [4]int {
+	return [4]int{
+		dlxCellColumns + c.row*numColumns + c.column,
+		dlxRowColumns + c.row*numCandidates + c.value,
+		dlxColumnColumns + c.column*numCandidates + c.value,
+		dlxSubGridColumns + subGridIndex(c.row, c.column)*numCandidates + c.value,
+	}
+}
+
+/*
+addRow appends the four nodes of a placement, linking each at the bottom of its column.
+*/
+func (m *dlxMatrix) addRow(c *candidate) {
+	var first int = len(m.left)
+	for i, index := range dlxColumnsOf(c) {
+		var node int = first + i
+		var column int = index + 1
+		m.left = append(m.left, first+(i+3)%4)
+		m.right = append(m.right, first+(i+1)%4)
+		m.up = append(m.up, m.up[column])
+		m.down = append(m.down, column)
+		m.header = append(m.header, column)
+		m.placement = append(m.placement, c)
+		m.down[m.up[column]] = node
+		m.up[column] = node
+		m.size[column]++
+	}
+}
+
+func (m *dlxMatrix) cover(column int) {
+	m.right[m.left[column]] = m.right[column]
+	m.left[m.right[column]] = m.left[column]
+	for row := m.down[column]; row != column; row = m.down[row] {
+		for node := m.right[row]; node != row; node = m.right[node] {
+			m.down[m.up[node]] = m.down[node]
+			m.up[m.down[node]] = m.up[node]
+			m.size[m.header[node]]--
+		}
+	}
+}
+
+func (m *dlxMatrix) uncover(column int) {
+	for row := m.up[column]; row != column; row = m.up[row] {
+		for node := m.left[row]; node != row; node = m.left[node] {
+			m.size[m.header[node]]++
+			m.down[m.up[node]] = node
+			m.up[m.down[node]] = node
+		}
+	}
+	m.right[m.left[column]] = column
+	m.left[m.right[column]] = column
+}
+
+/*
+smallestColumn returns the open column with the fewest rows, or 0 when every column is covered.
+*/
+func (m *dlxMatrix) smallestColumn() int {
+	var best int = 0
+	for column := m.right[0]; column != 0; column = m.right[column] {
+		if best == 0 || m.size[column] < m.size[best] {
+			best = column
+			if m.size[best] <= 1 {
+				break
+			}
+		}
+	}
+
+	return best
+}
+
+/*
+dlxSearch walks the matrix, mirroring the chosen rows into gs.  It stops when visit asks it to, or
+when the iteration limit is hit or ctx is done, with gs left holding the placements of the current
+branch.  path holds the row chosen at each depth of that branch.
+*/
+type dlxSearch struct {
+	ctx              context.Context
+	matrix           *dlxMatrix
+	gs               *gameState
+	statistics       *GamePlayStatistics
+	maxIterations    int
+	snapShotInterval int
+	reportInterval   int
+	observer         Observer
+	visit            func(gs *gameState) bool
+	path             []int
+}
+
+/*
+run returns true once the search has to stop, either because visit said so or with the error that
+cut it short.
+*/
+func (search *dlxSearch) run() (bool, error) {
+	var m *dlxMatrix = search.matrix
+	var column int = m.smallestColumn()
+	if column == 0 {
+		if search.observer != nil {
+			search.observer.Observe(Event{
+				Kind:      EventSolution,
+				Iteration: search.statistics.Iterations,
+				Depth:     len(search.path),
+				Solution:  search.gs.toGame(),
+			})
+		}
+		return !search.visit(search.gs), nil
+	}
+
+	search.statistics.expand(len(search.path), m.size[column])
+	m.cover(column)
+	for row := m.down[column]; row != column; row = m.down[row] {
+		if search.ctx.Err() != nil {
+			return true, stopped(search.ctx, search.statistics)
+		}
+		if search.statistics.Iterations >= search.maxIterations {
+			return true, ErrMaximumIterations
+		}
+		search.statistics.Iterations++
+
+		for node := m.right[row]; node != row; node = m.right[node] {
+			m.cover(m.header[node])
+		}
+		search.gs.addCandidate(m.placement[row])
+		search.path = append(search.path, row)
+		search.statistics.place(len(search.path))
+		search.gs.keepBest(len(search.path))
+		search.statistics.snapShot(search.snapShotInterval, len(search.path), search.remaining)
+		search.observe(m.placement[row])
+
+		stop, err := search.run()
+		if stop {
+			return stop, err
+		}
+
+		search.path = search.path[:len(search.path)-1]
+		search.statistics.BackTracks++
+		if search.observer != nil {
+			search.observer.Observe(Event{
+				Kind:      EventBackTrack,
+				Iteration: search.statistics.Iterations,
+				Depth:     len(search.path),
+				TakenBack: 1,
+			})
+		}
+		search.gs.removeCandidate(m.placement[row])
+		for node := m.left[row]; node != row; node = m.left[node] {
+			m.uncover(m.header[node])
+		}
+	}
+	m.uncover(column)
+
+	return false, nil
+}
+
+/*
+observe reports the placement of c, and the progress of the search when a report is due.
+*/
+func (search *dlxSearch) observe(c *candidate) {
+	if search.observer == nil {
+		return
+	}
+
+	var iteration int = search.statistics.Iterations
+	search.observer.Observe(placementEvent(c, iteration, len(search.path), false))
+	if search.reportInterval > 0 && iteration%search.reportInterval == 0 {
+		search.observer.Observe(Event{
+			Kind:       EventProgress,
+			Iteration:  iteration,
+			Depth:      len(search.path),
+			BackTracks: search.statistics.BackTracks,
+			Remaining:  search.remaining(),
+			SetCount:   search.gs.setCount(),
+		})
+	}
+}
+
+/*
+remaining counts the rows below each row of the path in its column, the branches left to try at
+each depth.
+*/
+func (search *dlxSearch) remaining() []int {
+	var m *dlxMatrix = search.matrix
+	var ret []int = make([]int, len(search.path))
+	for depth, row := range search.path {
+		for node := m.down[row]; node != m.header[row]; node = m.down[node] {
+			ret[depth]++
+		}
+	}
+
+	return ret
+}
+
+func (engine DLXEngine) search(ctx context.Context, solver *Solver, gs *gameState, statistics *GamePlayStatistics,
+	visit func(gs *gameState) bool) error {
+	var search *dlxSearch = &dlxSearch{
+		ctx:              ctx,
+		matrix:           newDLXMatrix(gs),
+		gs:               gs,
+		statistics:       statistics,
+		maxIterations:    solver.MaximumIterations,
+		snapShotInterval: solver.SnapShotInterval,
+		reportInterval:   solver.IterationReportInterval,
+		observer:         solver.observer(),
+		visit:            visit,
+		path:             make([]int, 0, numRows*numColumns),
+	}
+
+	_, err := search.run()
+	return err
+}
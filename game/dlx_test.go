@@ -0,0 +1,76 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Arto Inkala's puzzle, published as the hardest sudoku in 2012.
+*/
+const hardPuzzle string = "8..........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4.."
+const hardPuzzleSolution string = "812753649943682175675491283154237896369845721287169534521974368438526917796318452"
+
+func Test_DLXEngine_solve(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.Engine = DLXEngine{}
+
+	for _, test := range []struct {
+		puzzle   string
+		solution string
+	}{
+		{easyPuzzle, easyPuzzleSolution},
+		{hardPuzzle, hardPuzzleSolution},
+	} {
+		puzzle, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+
+		solution, stats, err := solver.Solve(puzzle)
+		assert.Nil(t, err)
+		assert.Equal(t, test.solution, FormatLine(solution))
+		assert.True(t, solution.IsGiven(0, 0))
+		assert.False(t, solution.IsGiven(0, 2))
+		assert.Greater(t, stats.Iterations, 0)
+	}
+}
+
+func Test_DLXEngine_solve_empty(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.Engine = DLXEngine{}
+
+	solution, _, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.True(t, isFinished(&gameState{Grid: solution.Grid}))
+	assert.Nil(t, validateGameState(&gameState{Grid: solution.Grid}))
+}
+
+func Test_DLXEngine_solve_noSolution(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.Engine = DLXEngine{}
+
+	/*
+		The first row can only be finished with a 9, which the last column already holds.
+	*/
+	puzzle, err := ParseGame("12345678." + "........." + "........." + "........." + "........." +
+		"........." + "........." + "........." + "........9")
+	assert.Nil(t, err)
+
+	_, _, err = solver.Solve(puzzle)
+	assert.ErrorIs(t, err, ErrNoSolution)
+}
+
+func Test_DLXEngine_solve_maximumIterations(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.Engine = DLXEngine{}
+	solver.MaximumIterations = 10
+
+	puzzle, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	partial, stats, err := solver.Solve(puzzle)
+	assert.ErrorIs(t, err, ErrMaximumIterations)
+	assert.Equal(t, 10, stats.Iterations)
+	assert.NotNil(t, partial)
+	assert.Equal(t, 7, partial.Grid[0][0])
+}
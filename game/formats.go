@@ -0,0 +1,369 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Format identifies one of the puzzle file formats the package can read and write.  Every format
+writes the givens of a game; only SadManFormat also keeps the cells filled in since, in its [State]
+section, so a game in progress comes back as the bare puzzle from the others.
+*/
+type Format int
+
+const (
+	/*
+		LineFormat is the 81 character line format, one puzzle per line.
+	*/
+	LineFormat Format = iota
+	/*
+		SadManFormat is the SadMan Sudoku .sdk format with its [Puzzle] and [State] sections.
+	*/
+	SadManFormat
+	/*
+		SimpleSudokuFormat is the Simple Sudoku .ss format with '|' and '-' box separators.
+	*/
+	SimpleSudokuFormat
+	/*
+		SudoCueFormat is the SudoCue .sdm format, one 81 digit puzzle per line with 0 for blanks.
+	*/
+	SudoCueFormat
+)
+
+func (f Format) String() string {
+	switch f {
+	case LineFormat:
+		return "line"
+	case SadManFormat:
+		return "sdk"
+	case SimpleSudokuFormat:
+		return "ss"
+	case SudoCueFormat:
+		return "sdm"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+/*
+FormatForFileName picks a format from the extension of name, falling back to LineFormat.
+*/
+func FormatForFileName(name string) Format {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".sdk":
+		return SadManFormat
+	case ".ss":
+		return SimpleSudokuFormat
+	case ".sdm":
+		return SudoCueFormat
+	default:
+		return LineFormat
+	}
+}
+
+/*
+ReadFormat reads every puzzle in r.  The .sdk and .ss formats hold a single puzzle.
+*/
+func ReadFormat(r io.Reader, format Format) ([]*Game, error) {
+	switch format {
+	case SadManFormat:
+		game, err := ReadSDK(r)
+		if err != nil {
+			return nil, err
+		}
+		return []*Game{game}, nil
+	case SimpleSudokuFormat:
+		game, err := ReadSS(r)
+		if err != nil {
+			return nil, err
+		}
+		return []*Game{game}, nil
+	case LineFormat, SudoCueFormat:
+		return ReadSDM(r)
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown format: %v", format))
+	}
+}
+
+/*
+WriteFormat writes games to w, see Format for which cells each format keeps.  The .sdk and .ss
+formats hold a single puzzle.
+*/
+func WriteFormat(w io.Writer, format Format, games ...*Game) error {
+	switch format {
+	case SadManFormat, SimpleSudokuFormat:
+		if len(games) != 1 {
+			return errors.New(fmt.Sprintf("the %v format holds exactly one puzzle, got %d", format, len(games)))
+		}
+		if format == SadManFormat {
+			return WriteSDK(w, games[0])
+		}
+		return WriteSS(w, games[0])
+	case LineFormat:
+		for _, game := range games {
+			_, err := io.WriteString(w, formatCells(game, '.', game.IsGiven)+"\n")
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case SudoCueFormat:
+		return WriteSDM(w, games...)
+	default:
+		return errors.New(fmt.Sprintf("unknown format: %v", format))
+	}
+}
+
+/*
+FormatLine returns game in the 81 character line format with '.' for blanks.
+*/
+func FormatLine(game *Game) string {
+	return formatCells(game, '.', func(row, column int) bool { return true })
+}
+
+func formatCells(game *Game, blank byte, include func(row, column int) bool) string {
+	var buf bytes.Buffer
+	for row := 0; row < numRows; row++ {
+		buf.WriteString(formatRow(game, row, blank, include))
+	}
+
+	return buf.String()
+}
+
+func formatRow(game *Game, row int, blank byte, include func(row, column int) bool) string {
+	var buf bytes.Buffer
+	for column := 0; column < numColumns; column++ {
+		var value int = game.Grid[row][column]
+		if value == NotSet || !include(row, column) {
+			buf.WriteByte(blank)
+		} else {
+			buf.WriteByte(byte('1' + value))
+		}
+	}
+
+	return buf.String()
+}
+
+/*
+ReadSDK reads a SadMan Sudoku .sdk file.
+
+Lines starting with '#' are header fields and are skipped.  The [Puzzle] section holds the givens
+and the optional [State] section holds the grid as it was saved, so its extra digits come back as
+filled, non given, cells.  A file without sections is read as a bare puzzle.
+*/
+func ReadSDK(r io.Reader) (*Game, error) {
+	sections, err := readSections(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var puzzleLines sectionLines = sections["puzzle"]
+	if puzzleLines.text == "" {
+		puzzleLines = sections[""]
+	}
+	if puzzleLines.text == "" {
+		return nil, errors.New("sdk file has no puzzle")
+	}
+
+	puzzle, err := parseGame(puzzleLines.text, puzzleLines.firstLine)
+	if err != nil {
+		return nil, err
+	}
+
+	var stateLines sectionLines = sections["state"]
+	if stateLines.text == "" {
+		return puzzle, nil
+	}
+
+	state, err := parseGame(stateLines.text, stateLines.firstLine)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Given = make([][]bool, numRows)
+	for row := 0; row < numRows; row++ {
+		state.Given[row] = make([]bool, numColumns)
+		for column := 0; column < numColumns; column++ {
+			var given int = puzzle.Grid[row][column]
+			if given == NotSet {
+				continue
+			}
+			if state.Grid[row][column] != given {
+				return nil, errors.New(fmt.Sprintf("sdk state does not match the given %d at row %d, column %d",
+					given+1, row+1, column+1))
+			}
+			state.Given[row][column] = true
+		}
+	}
+
+	return state, nil
+}
+
+/*
+WriteSDK writes game as a SadMan Sudoku .sdk file.  A [State] section is written when the game has
+cells that are filled but not given.
+*/
+func WriteSDK(w io.Writer, game *Game) error {
+	var buf bytes.Buffer
+	var filled bool = false
+
+	buf.WriteString("[Puzzle]\n")
+	for row := 0; row < numRows; row++ {
+		buf.WriteString(formatRow(game, row, '.', game.IsGiven))
+		buf.WriteString("\n")
+		for column := 0; column < numColumns; column++ {
+			if game.Grid[row][column] != NotSet && !game.IsGiven(row, column) {
+				filled = true
+			}
+		}
+	}
+
+	if filled {
+		buf.WriteString("[State]\n")
+		for row := 0; row < numRows; row++ {
+			buf.WriteString(formatRow(game, row, '.', func(row, column int) bool { return true }))
+			buf.WriteString("\n")
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+/*
+ReadSS reads a Simple Sudoku .ss file.  Separator lines made of '-', '+', '*' and '|' are skipped
+and 'X' is accepted as a blank alongside '.'.
+*/
+func ReadSS(r io.Reader) (*Game, error) {
+	var scanner *bufio.Scanner = bufio.NewScanner(r)
+	var text strings.Builder
+	var line, firstLine, lastLine int
+
+	for scanner.Scan() {
+		line++
+		var current string = scanner.Text()
+		if strings.Trim(current, "-+*| \t\r") == "" {
+			continue
+		}
+		if firstLine == 0 {
+			firstLine = line
+			lastLine = line
+		}
+		/*
+			Keep a newline for every skipped line so positions in errors match the file.
+		*/
+		text.WriteString(strings.Repeat("\n", line-lastLine))
+		text.WriteString(strings.NewReplacer("X", ".", "x", ".").Replace(current))
+		lastLine = line
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if firstLine == 0 {
+		return nil, errors.New("ss file has no puzzle")
+	}
+
+	return parseGame(text.String(), firstLine)
+}
+
+/*
+WriteSS writes the givens of game as a Simple Sudoku .ss file.
+*/
+func WriteSS(w io.Writer, game *Game) error {
+	var buf bytes.Buffer
+	for row := 0; row < numRows; row++ {
+		if row != 0 && row%subGridRows == 0 {
+			buf.WriteString("-----------\n")
+		}
+		var line string = formatRow(game, row, '.', game.IsGiven)
+		buf.WriteString(line[0:3] + "|" + line[3:6] + "|" + line[6:9] + "\n")
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+/*
+ReadSDM reads a SudoCue .sdm file, one puzzle per line.  The reader is the same as PuzzleReader, so
+any of the blank characters of the line format are accepted.
+*/
+func ReadSDM(r io.Reader) ([]*Game, error) {
+	var reader *PuzzleReader = NewPuzzleReader(r)
+	var games []*Game = make([]*Game, 0)
+
+	for {
+		puzzle, err := reader.Next()
+		if err == io.EOF {
+			return games, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, puzzle.Game)
+	}
+}
+
+/*
+WriteSDM writes the givens of each game as a SudoCue .sdm line with '0' for blanks.
+*/
+func WriteSDM(w io.Writer, games ...*Game) error {
+	for _, game := range games {
+		_, err := io.WriteString(w, formatCells(game, '0', game.IsGiven)+"\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type sectionLines struct {
+	text      string
+	firstLine int
+	lastLine  int
+}
+
+/*
+readSections splits an .sdk file into its bracketed sections keyed by lower case name.  Lines before
+the first section are stored under "".
+*/
+func readSections(r io.Reader) (map[string]sectionLines, error) {
+	var scanner *bufio.Scanner = bufio.NewScanner(r)
+	var sections map[string]sectionLines = make(map[string]sectionLines)
+	var name string = ""
+	var line int = 0
+
+	for scanner.Scan() {
+		line++
+		var current string = strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(current, "#") || current == "" {
+			continue
+		}
+		if strings.HasPrefix(current, "[") && strings.HasSuffix(current, "]") {
+			name = strings.ToLower(current[1 : len(current)-1])
+			continue
+		}
+
+		var section sectionLines = sections[name]
+		if section.text == "" {
+			section.firstLine = line
+			section.lastLine = line
+		}
+		section.text += strings.Repeat("\n", line-section.lastLine) + current
+		section.lastLine = line
+		sections[name] = section
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
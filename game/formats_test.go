@@ -0,0 +1,134 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatForFileName(t *testing.T) {
+	assert.Equal(t, SadManFormat, FormatForFileName("archive/puzzle.SDK"))
+	assert.Equal(t, SimpleSudokuFormat, FormatForFileName("puzzle.ss"))
+	assert.Equal(t, SudoCueFormat, FormatForFileName("collection.sdm"))
+	assert.Equal(t, LineFormat, FormatForFileName("collection.txt"))
+}
+
+func Test_FormatLine(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	assert.Equal(t, easyPuzzle, FormatLine(game))
+}
+
+func Test_ReadSDK(t *testing.T) {
+	var rows string = "53..7....\n6..195...\n.98....6.\n8...6...3\n4..8.3..1\n7...2...6\n.6....28.\n...419..5\n....8..79\n"
+	var input string = "#Asomeone\n#Dnewspaper\n[Puzzle]\n" + rows +
+		"[State]\n" +
+		"534.7....\n6..195...\n.98....6.\n8...6...3\n4..8.3..1\n7...2...6\n.6....28.\n...419..5\n....8..79\n"
+
+	game, err := ReadSDK(strings.NewReader(input))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, game.Grid[0][2])
+	assert.False(t, game.IsGiven(0, 2))
+	assert.True(t, game.IsGiven(0, 1))
+	assert.Equal(t, easyPuzzle, FormatLine(game.Givens()))
+
+	var buf bytes.Buffer
+	err = WriteSDK(&buf, game)
+	assert.Nil(t, err)
+	assert.Equal(t, input[strings.Index(input, "[Puzzle]"):], buf.String())
+
+	/* no sections */
+	game, err = ReadSDK(strings.NewReader(rows))
+	assert.Nil(t, err)
+	assert.Equal(t, easyPuzzle, FormatLine(game))
+
+	/* the state has to keep the givens */
+	_, err = ReadSDK(strings.NewReader(strings.Replace(input, "534.7....", "734.7....", 1)))
+	assert.NotNil(t, err)
+
+	_, err = ReadSDK(strings.NewReader("#Aonly a header\n"))
+	assert.NotNil(t, err)
+
+	_, err = ReadSDK(strings.NewReader("[Puzzle]\n\n53..7...x\n"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 3, column 9")
+}
+
+func Test_ReadSS(t *testing.T) {
+	var input string = "53.|.7.|...\n6..|195|...\n.98|...|.6.\n-----------\n" +
+		"8..|.6.|..3\n4..|8.3|..1\n7..|.2.|..6\n-----------\n" +
+		".6.|...|28.\n...|419|..5\n...|.8.|.79\n"
+
+	game, err := ReadSS(strings.NewReader(input))
+	assert.Nil(t, err)
+	assert.Equal(t, easyPuzzle, FormatLine(game))
+
+	var buf bytes.Buffer
+	err = WriteSS(&buf, game)
+	assert.Nil(t, err)
+	assert.Equal(t, input, buf.String())
+
+	game, err = ReadSS(strings.NewReader(strings.Replace(input, ".", "X", -1)))
+	assert.Nil(t, err)
+	assert.Equal(t, easyPuzzle, FormatLine(game))
+
+	_, err = ReadSS(strings.NewReader(strings.Replace(input, "..5", "..?", 1)))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 10")
+}
+
+func Test_ReadSDM(t *testing.T) {
+	var zeros string = strings.Replace(easyPuzzle, ".", "0", -1)
+	games, err := ReadSDM(strings.NewReader(zeros + "\n" + easyPuzzleSolution + "\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(games))
+	assert.Equal(t, easyPuzzle, FormatLine(games[0]))
+
+	var buf bytes.Buffer
+	err = WriteSDM(&buf, games...)
+	assert.Nil(t, err)
+	assert.Equal(t, zeros+"\n"+easyPuzzleSolution+"\n", buf.String())
+}
+
+func Test_WriteFormat(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	for _, format := range []Format{LineFormat, SadManFormat, SimpleSudokuFormat, SudoCueFormat} {
+		var buf bytes.Buffer
+		err = WriteFormat(&buf, format, game)
+		assert.Nil(t, err, format.String())
+
+		games, err := ReadFormat(&buf, format)
+		assert.Nil(t, err, format.String())
+		assert.Equal(t, 1, len(games), format.String())
+		assert.Equal(t, game.Grid, games[0].Grid, format.String())
+	}
+
+	/*
+		A game in progress comes back as its givens, but for the .sdk [State] section.
+	*/
+	gs, err := createGame(game)
+	assert.Nil(t, err)
+	gs.addCandidate(&candidate{row: 0, column: 2, value: 3})
+	var filled *Game = gs.toGame()
+	for _, format := range []Format{LineFormat, SadManFormat, SimpleSudokuFormat, SudoCueFormat} {
+		var buf bytes.Buffer
+		err = WriteFormat(&buf, format, filled)
+		assert.Nil(t, err, format.String())
+
+		games, err := ReadFormat(&buf, format)
+		assert.Nil(t, err, format.String())
+		if format == SadManFormat {
+			assert.Equal(t, filled.Grid, games[0].Grid)
+			assert.False(t, games[0].IsGiven(0, 2))
+		} else {
+			assert.Equal(t, game.Grid, games[0].Grid, format.String())
+		}
+	}
+
+	err = WriteFormat(&bytes.Buffer{}, SadManFormat, game, game)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,286 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+HintLevel is how much of a Hint to give away, from a nudge in the right direction up to the answer.
+*/
+type HintLevel int
+
+const (
+	/*
+		HintNudge says where to look, such as a box or a digit.
+	*/
+	HintNudge HintLevel = iota
+	/*
+		HintTechnique names the technique to use.
+	*/
+	HintTechnique
+	/*
+		HintCells points out the cells of the pattern.
+	*/
+	HintCells
+	/*
+		HintResult gives the placement or eliminations that follow.
+	*/
+	HintResult
+)
+
+/*
+Hint is the next step for a player, to be given away a level at a time, see Text.  When the board
+holds Mistakes the hint is about them instead and Step is nil; Cells are then the cells of the
+mistakes.
+*/
+type Hint struct {
+	Nudge      string
+	Technique  string
+	Difficulty int
+	Cells      []Cell
+	Step       *Step
+	Mistakes   []Mistake
+}
+
+/*
+Mistake is a cell where the player's board disagrees with the solution: an entry of the wrong
+Digit or, when Eliminated, the candidate Digit taken out though it is the Solution.  Digits are 1
+through 9.
+*/
+type Mistake struct {
+	Cell       Cell
+	Digit      int
+	Solution   int
+	Eliminated bool
+}
+
+func (mistake Mistake) String() string {
+	var cell string = cellNames([]Cell{mistake.Cell})
+	if mistake.Eliminated {
+		return fmt.Sprintf("%s needs the %d that was taken out", cell, mistake.Digit)
+	}
+
+	return fmt.Sprintf("%s holds %d, not %d", cell, mistake.Digit, mistake.Solution)
+}
+
+/*
+ErrNotUnique is returned when mistakes cannot be told because the givens have more than one
+solution.
+*/
+var ErrNotUnique = errors.New("puzzle has more than one solution")
+
+/*
+Text describes hint at level.
+*/
+func (hint *Hint) Text(level HintLevel) string {
+	switch level {
+	case HintNudge:
+		return hint.Nudge
+	case HintTechnique:
+		if len(hint.Mistakes) > 0 {
+			return "Some of your entries do not match the solution"
+		}
+		return fmt.Sprintf("Try %s", hint.Technique)
+	case HintCells:
+		return "Look at " + cellNames(hint.Cells)
+	}
+
+	var parts []string
+	for _, mistake := range hint.Mistakes {
+		parts = append(parts, mistake.String())
+	}
+	if hint.Step != nil {
+		for _, placement := range hint.Step.Placements {
+			parts = append(parts, fmt.Sprintf("Place %s", placement))
+		}
+		if len(hint.Step.Eliminations) > 0 {
+			var removed []string = make([]string, len(hint.Step.Eliminations))
+			for i, elimination := range hint.Step.Eliminations {
+				removed[i] = elimination.String()
+			}
+			parts = append(parts, "Remove "+strings.Join(removed, ", "))
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+/*
+Hint returns the next step for the player's board in game: the easiest technique that applies,
+or the player's mistakes when the board disagrees with the unique solution of its givens.
+Eliminated are candidates the player has already taken out, such as those of earlier hints; a Game
+keeps no pencil marks, so without them a hint that only removes candidates would be given again.
+A cell or digit out of range is an error.
+
+The hint is nil when the board is solved.  The error is ErrStuck when no technique applies.  When
+the givens have more than one solution mistakes cannot be told, so the board is taken as it is and
+the techniques that need a unique solution are left out.
+*/
+func (ls *LogicalSolver) Hint(game *Game, eliminated ...CellDigit) (*Hint, error) {
+	if game == nil {
+		return nil, errors.New("game is nil on call to Hint.")
+	}
+	if err := checkCellDigits(eliminated); err != nil {
+		return nil, err
+	}
+
+	var solver LogicalSolver = *ls
+	mistakes, err := FindMistakes(game, eliminated...)
+	switch {
+	case errors.Is(err, ErrNotUnique):
+		solver.SkipUniqueness = true
+	case err != nil:
+		return nil, err
+	case len(mistakes) > 0:
+		var cells []Cell = make([]Cell, len(mistakes))
+		for i, mistake := range mistakes {
+			cells[i] = mistake.Cell
+		}
+		return &Hint{Nudge: "Check your entries", Cells: cells, Mistakes: mistakes}, nil
+	}
+
+	grid, err := newCandidateGrid(game)
+	if err != nil {
+		return nil, err
+	}
+	if grid.solved() {
+		return nil, nil
+	}
+	grid.apply(&Step{Eliminations: eliminated})
+
+	var step *Step = solver.next(grid)
+	if step == nil {
+		return nil, ErrStuck
+	}
+
+	return &Hint{
+		Nudge:      nudge(step),
+		Technique:  step.Technique,
+		Difficulty: step.Difficulty,
+		Cells:      step.Cells,
+		Step:       step,
+	}, nil
+}
+
+/*
+FindMistakes compares the player's board in game with the unique solution of its givens and
+returns every entry that differs, and every candidate of eliminated that the solution needs, in
+reading order.  The error is ErrNoSolution or ErrNotUnique when the givens do not have exactly one
+solution, and reports the first of eliminated with a cell or digit out of range.
+*/
+func FindMistakes(game *Game, eliminated ...CellDigit) ([]Mistake, error) {
+	if game == nil {
+		return nil, errors.New("game is nil on call to FindMistakes.")
+	}
+	if err := checkCellDigits(eliminated); err != nil {
+		return nil, err
+	}
+
+	var solutions []*Game
+	err := CreateSolver().EachSolution(game.Givens(), func(solution *Game) bool {
+		solutions = append(solutions, solution)
+		return len(solutions) < 2
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case len(solutions) == 0:
+		return nil, ErrNoSolution
+	case len(solutions) > 1:
+		return nil, ErrNotUnique
+	}
+
+	var solution [][]int = solutions[0].Grid
+	var ret []Mistake
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var value int = game.Grid[row][column]
+			if value != NotSet && value != solution[row][column] {
+				ret = append(ret, Mistake{
+					Cell:     Cell{Row: row, Column: column},
+					Digit:    value + 1,
+					Solution: solution[row][column] + 1,
+				})
+			}
+			for _, elimination := range eliminated {
+				if elimination.Cell == (Cell{Row: row, Column: column}) && value == NotSet &&
+					elimination.Digit == solution[row][column]+1 {
+					ret = append(ret, Mistake{
+						Cell:       elimination.Cell,
+						Digit:      elimination.Digit,
+						Solution:   elimination.Digit,
+						Eliminated: true,
+					})
+				}
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+/*
+checkCellDigits returns an error for the first of cellDigits with a cell off the board or a digit
+outside 1 through 9.
+*/
+func checkCellDigits(cellDigits []CellDigit) error {
+	for _, cd := range cellDigits {
+		if cd.Cell.Row < 0 || cd.Cell.Row >= numRows || cd.Cell.Column < 0 || cd.Cell.Column >= numColumns {
+			return errors.New(fmt.Sprintf("cell %d,%d is off the board", cd.Cell.Row, cd.Cell.Column))
+		}
+		if cd.Digit < 1 || cd.Digit > numCandidates {
+			return errors.New(fmt.Sprintf("digit %d at r%dc%d is not 1 through 9", cd.Digit, cd.Cell.Row+1, cd.Cell.Column+1))
+		}
+	}
+
+	return nil
+}
+
+/*
+nudge says where to look for step without giving it away: the box, row or column holding all its
+cells, the box of its placement, the digit it is about, or the box of its first elimination.
+*/
+func nudge(step *Step) string {
+	if len(step.Cells) > 0 {
+		/*
+			commonUnits lists the sub grid last, and a box is the easiest place to look.
+		*/
+		var shared []int = commonUnits(step.Cells)
+		if len(shared) > 0 {
+			var unit int = shared[len(shared)-1]
+			switch {
+			case unit >= numRows+numColumns:
+				return fmt.Sprintf("Look at box %d", unit-numRows-numColumns+1)
+			case unit >= numRows:
+				return fmt.Sprintf("Look at column %d", unit-numRows+1)
+			default:
+				return fmt.Sprintf("Look at row %d", unit+1)
+			}
+		}
+	}
+	if len(step.Placements) == 0 && len(step.Digits) == 1 {
+		return fmt.Sprintf("Look at the %ds", step.Digits[0])
+	}
+
+	var cell Cell
+	if len(step.Placements) > 0 {
+		cell = step.Placements[0].Cell
+	} else if len(step.Eliminations) > 0 {
+		cell = step.Eliminations[0].Cell
+	}
+	return fmt.Sprintf("Look at box %d", subGridIndex(cell.Row, cell.Column)+1)
+}
+
+/*
+cellNames lists cells as r1c1 and so on.
+*/
+func cellNames(cells []Cell) string {
+	var names []string = make([]string, len(cells))
+	for i, cell := range cells {
+		names[i] = fmt.Sprintf("r%dc%d", cell.Row+1, cell.Column+1)
+	}
+
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+playerGame returns puzzle with its givens marked, so cells filled in later count as the player's.
+*/
+func playerGame(t *testing.T, puzzle string) *Game {
+	var game *Game = mustParse(t, puzzle)
+	game.Given = game.givenMask()
+
+	return game
+}
+
+func TestLogicalSolver_Hint(t *testing.T) {
+	var game *Game = playerGame(t, easyPuzzle)
+	var solver *LogicalSolver = &LogicalSolver{}
+	hint, err := solver.Hint(game)
+	assert.Nil(t, err)
+	assert.Empty(t, hint.Mistakes)
+	assert.Equal(t, HiddenSingle.Name, hint.Technique)
+	assert.Equal(t, HiddenSingle.Difficulty, hint.Difficulty)
+	assert.Len(t, hint.Step.Placements, 1)
+	var placement CellDigit = hint.Step.Placements[0]
+	assert.Equal(t, easyPuzzleSolution[placement.Cell.Row*numColumns+placement.Cell.Column], byte('0'+placement.Digit))
+
+	assert.True(t, strings.HasPrefix(hint.Text(HintNudge), "Look at box "), hint.Text(HintNudge))
+	assert.Equal(t, "Try Hidden Single", hint.Text(HintTechnique))
+	assert.Equal(t, "Look at "+cellNames(hint.Cells), hint.Text(HintCells))
+	assert.Equal(t, "Place "+placement.String(), hint.Text(HintResult))
+
+	/*
+		Following the hints solves the puzzle, and a solved board needs no hint.
+	*/
+	for hint != nil {
+		for _, placement := range hint.Step.Placements {
+			game.Grid[placement.Cell.Row][placement.Cell.Column] = placement.Digit - 1
+		}
+		hint, err = solver.Hint(game)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, easyPuzzleSolution, FormatLine(game))
+	assert.False(t, game.IsGiven(0, 2))
+
+	_, err = solver.Hint(nil)
+	assert.NotNil(t, err)
+}
+
+func TestLogicalSolver_Hint_eliminated(t *testing.T) {
+	/*
+		A hint that only removes candidates moves on once they are passed back.
+	*/
+	var game *Game = playerGame(t, easyPuzzle)
+	var solver *LogicalSolver = &LogicalSolver{Techniques: []Technique{XWing}}
+	first, err := solver.Hint(game)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, first.Step.Eliminations)
+	assert.True(t, strings.HasPrefix(first.Text(HintResult), "Remove "), first.Text(HintResult))
+
+	second, err := solver.Hint(game, first.Step.Eliminations...)
+	if err == nil {
+		assert.NotEqual(t, first.Step.Eliminations, second.Step.Eliminations)
+	} else {
+		assert.ErrorIs(t, err, ErrStuck)
+	}
+}
+
+func TestLogicalSolver_Hint_mistakes(t *testing.T) {
+	var game *Game = playerGame(t, easyPuzzle)
+	var cell Cell = Cell{Row: 0, Column: 2}
+	var right int = int(easyPuzzleSolution[2] - '0')
+	var wrong int = right%numCandidates + 1
+	game.Grid[cell.Row][cell.Column] = wrong - 1
+
+	hint, err := (&LogicalSolver{}).Hint(game)
+	assert.Nil(t, err)
+	assert.Nil(t, hint.Step)
+	assert.Equal(t, []Mistake{{Cell: cell, Digit: wrong, Solution: right}}, hint.Mistakes)
+	assert.Equal(t, []Cell{cell}, hint.Cells)
+	assert.Equal(t, "Check your entries", hint.Text(HintNudge))
+	assert.Equal(t, "Look at r1c3", hint.Text(HintCells))
+	assert.Equal(t, hint.Mistakes[0].String(), hint.Text(HintResult))
+
+	/*
+		Taking out a candidate the solution needs is a mistake too.
+	*/
+	game.Grid[cell.Row][cell.Column] = NotSet
+	mistakes, err := FindMistakes(game, CellDigit{Cell: cell, Digit: wrong}, CellDigit{Cell: cell, Digit: right})
+	assert.Nil(t, err)
+	assert.Equal(t, []Mistake{{Cell: cell, Digit: right, Solution: right, Eliminated: true}}, mistakes)
+	assert.Equal(t, "r1c3 needs the "+string(rune('0'+right))+" that was taken out", mistakes[0].String())
+
+	mistakes, err = FindMistakes(game)
+	assert.Nil(t, err)
+	assert.Empty(t, mistakes)
+}
+
+func TestLogicalSolver_Hint_notUnique(t *testing.T) {
+	/*
+		With two solutions there are no mistakes to find, and a unique rectangle would be wrong.
+	*/
+	var game *Game = playerGame(t, twoSolutionPuzzle)
+	_, err := FindMistakes(game)
+	assert.ErrorIs(t, err, ErrNotUnique)
+
+	hint, err := (&LogicalSolver{}).Hint(game)
+	assert.Nil(t, hint)
+	assert.ErrorIs(t, err, ErrStuck)
+
+	_, err = FindMistakes(mustParse(t, "12345678."+strings.Repeat(".", 71)+"9"))
+	assert.ErrorIs(t, err, ErrNoSolution)
+}
+
+func Test_nudge(t *testing.T) {
+	assert.Equal(t, "Look at box 1", nudge(&Step{Cells: []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}}}))
+	assert.Equal(t, "Look at row 1", nudge(&Step{Cells: []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 8}}}))
+	assert.Equal(t, "Look at column 9", nudge(&Step{Cells: []Cell{{Row: 0, Column: 8}, {Row: 8, Column: 8}}}))
+	assert.Equal(t, "Look at the 5s", nudge(&Step{
+		Cells:        []Cell{{Row: 0, Column: 0}, {Row: 4, Column: 4}},
+		Digits:       []int{5},
+		Eliminations: []CellDigit{{Cell: Cell{Row: 8, Column: 8}, Digit: 5}},
+	}))
+	assert.Equal(t, "Look at box 9", nudge(&Step{
+		Cells:        []Cell{{Row: 0, Column: 0}, {Row: 4, Column: 4}},
+		Digits:       []int{5, 6},
+		Eliminations: []CellDigit{{Cell: Cell{Row: 8, Column: 8}, Digit: 5}},
+	}))
+}
+
+func TestLogicalSolver_Hint_outOfRange(t *testing.T) {
+	var game *Game = playerGame(t, easyPuzzle)
+	for _, eliminated := range []CellDigit{
+		{Cell: Cell{Row: 0, Column: 2}, Digit: 0},
+		{Cell: Cell{Row: 0, Column: 2}, Digit: 10},
+		{Cell: Cell{Row: 9, Column: 2}, Digit: 1},
+		{Cell: Cell{Row: 0, Column: -1}, Digit: 1},
+	} {
+		hint, err := (&LogicalSolver{}).Hint(game, eliminated)
+		assert.Nil(t, hint)
+		assert.NotNil(t, err, eliminated.String())
+
+		_, err = FindMistakes(game, eliminated)
+		assert.NotNil(t, err, eliminated.String())
+	}
+}
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+/*
+JSONVersion is the version of the JSON encoding written by this package.
+
+A Game is encoded as
+
+	{
+		"version": 1,
+		"grid": [[5, 3, null, ...], ...],
+		"given": [[true, true, false, ...], ...]
+	}
+
+grid holds nine rows of nine cells with the user facing digits 1-9 and null for an empty cell.
+given marks the cells that belong to the puzzle; a filled cell with given false was filled in by a
+player or the solver.  When given is missing every digit is a given.
+
+A SolveResult wraps the puzzle, its solution and the statistics of the solve:
+
+	{
+		"version": 1,
+		"puzzle": {...},
+		"solution": {...},
+		"statistics": {"backTracks": 0, "iterations": 52, "propagations": 0, "seed": 7, "maxDepth": 52, "nodesExpanded": 52,
+			"candidatesEvaluated": 52, "wallTime": 1843000},
+		"error": "..."
+	}
+
+solution and statistics are left out when the solver failed, in which case error says why.
+*/
+const JSONVersion int = 1
+
+type jsonGame struct {
+	Version int      `json:"version"`
+	Grid    [][]*int `json:"grid"`
+	Given   [][]bool `json:"given,omitempty"`
+}
+
+func (gs *Game) MarshalJSON() ([]byte, error) {
+	var encoded jsonGame = jsonGame{
+		Version: JSONVersion,
+		Grid:    make([][]*int, numRows),
+		Given:   make([][]bool, numRows),
+	}
+
+	if len(gs.Grid) != numRows {
+		return nil, errors.New(fmt.Sprintf("game has %d rows, expected %d", len(gs.Grid), numRows))
+	}
+
+	for row := 0; row < numRows; row++ {
+		if len(gs.Grid[row]) != numColumns {
+			return nil, errors.New(fmt.Sprintf("row %d has %d columns, expected %d", row, len(gs.Grid[row]), numColumns))
+		}
+		encoded.Grid[row] = make([]*int, numColumns)
+		encoded.Given[row] = make([]bool, numColumns)
+		for column := 0; column < numColumns; column++ {
+			var value int = gs.Grid[row][column]
+			if value == NotSet {
+				continue
+			}
+			if value < 0 || value >= numCandidates {
+				return nil, errors.New(fmt.Sprintf("row %d, column %d holds invalid value %d", row, column, value))
+			}
+			var digit int = value + 1
+			encoded.Grid[row][column] = &digit
+			encoded.Given[row][column] = gs.IsGiven(row, column)
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+func (gs *Game) UnmarshalJSON(data []byte) error {
+	var decoded jsonGame
+	var err error = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Version != JSONVersion {
+		return errors.New(fmt.Sprintf("unsupported game version %d, expected %d", decoded.Version, JSONVersion))
+	}
+	if len(decoded.Grid) != numRows {
+		return errors.New(fmt.Sprintf("grid has %d rows, expected %d", len(decoded.Grid), numRows))
+	}
+	if decoded.Given != nil && len(decoded.Given) != numRows {
+		return errors.New(fmt.Sprintf("given has %d rows, expected %d", len(decoded.Given), numRows))
+	}
+
+	var game *Game = NewGame()
+	if decoded.Given != nil {
+		game.Given = make([][]bool, numRows)
+	}
+
+	for row := 0; row < numRows; row++ {
+		if len(decoded.Grid[row]) != numColumns {
+			return errors.New(fmt.Sprintf("grid row %d has %d columns, expected %d", row, len(decoded.Grid[row]), numColumns))
+		}
+		if game.Given != nil {
+			if len(decoded.Given[row]) != numColumns {
+				return errors.New(fmt.Sprintf("given row %d has %d columns, expected %d", row, len(decoded.Given[row]), numColumns))
+			}
+			game.Given[row] = make([]bool, numColumns)
+		}
+
+		for column := 0; column < numColumns; column++ {
+			var digit *int = decoded.Grid[row][column]
+			var given bool = game.Given != nil && decoded.Given[row][column]
+			if digit == nil {
+				if given {
+					return errors.New(fmt.Sprintf("row %d, column %d is given but empty", row, column))
+				}
+				continue
+			}
+			if *digit < 1 || *digit > numCandidates {
+				return errors.New(fmt.Sprintf("row %d, column %d holds invalid digit %d", row, column, *digit))
+			}
+			game.Grid[row][column] = *digit - 1
+			if game.Given != nil {
+				game.Given[row][column] = given
+			}
+		}
+	}
+
+	*gs = *game
+	return nil
+}
+
+/*
+SolveResult is the JSON envelope for the outcome of solving a puzzle.
+*/
+type SolveResult struct {
+	Version    int                 `json:"version"`
+	Puzzle     *Game               `json:"puzzle"`
+	Solution   *Game               `json:"solution,omitempty"`
+	Statistics *GamePlayStatistics `json:"statistics,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+/*
+NewSolveResult builds the envelope from the values returned by Solver.Solve.
+*/
+func NewSolveResult(puzzle *Game, solution *Game, statistics *GamePlayStatistics, err error) *SolveResult {
+	var ret *SolveResult = &SolveResult{
+		Version:  JSONVersion,
+		Puzzle:   puzzle,
+		Solution: solution,
+	}
+
+	if err != nil {
+		ret.Error = err.Error()
+		ret.Solution = nil
+	} else {
+		ret.Statistics = statistics
+	}
+
+	return ret
+}
+
+func (result *SolveResult) UnmarshalJSON(data []byte) error {
+	type plain SolveResult
+	var decoded plain
+	var err error = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Version != JSONVersion {
+		return errors.New(fmt.Sprintf("unsupported result version %d, expected %d", decoded.Version, JSONVersion))
+	}
+
+	*result = SolveResult(decoded)
+	return nil
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Game_MarshalJSON(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(game)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(data), `{"version":1,"grid":[[5,3,null,null,7,null,null,null,null],`))
+	assert.Contains(t, string(data), `"given":[[true,true,false,false,true,false,false,false,false],`)
+
+	var decoded *Game = &Game{}
+	err = json.Unmarshal(data, decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, game.Grid, decoded.Grid)
+	assert.True(t, decoded.IsGiven(0, 0))
+	assert.False(t, decoded.IsGiven(0, 2))
+}
+
+func Test_Game_MarshalJSON_filled(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	gs, err := createGame(game)
+	assert.Nil(t, err)
+	gs.addCandidate(&candidate{row: 0, column: 2, value: 3})
+
+	var decoded *Game = &Game{}
+	err = json.Unmarshal([]byte(gs.Json()), decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, decoded.Grid[0][2])
+	assert.False(t, decoded.IsGiven(0, 2))
+	assert.True(t, decoded.IsGiven(0, 1))
+}
+
+func Test_Game_UnmarshalJSON_errors(t *testing.T) {
+	var game *Game = &Game{}
+	var row string = `[null,null,null,null,null,null,null,null,null]`
+	var grid string = "[" + strings.Repeat(row+",", 8) + row + "]"
+
+	err := json.Unmarshal([]byte(`{"version":1,"grid":`+grid+`}`), game)
+	assert.Nil(t, err)
+	assert.Nil(t, game.Given)
+
+	err = json.Unmarshal([]byte(`{"version":2,"grid":`+grid+`}`), game)
+	assert.NotNil(t, err)
+
+	err = json.Unmarshal([]byte(`{"version":1,"grid":[`+row+`]}`), game)
+	assert.NotNil(t, err)
+
+	err = json.Unmarshal([]byte(`{"version":1,"grid":`+strings.Replace(grid, "null", "10", 1)+`}`), game)
+	assert.NotNil(t, err)
+
+	var givenRow string = `[true,false,false,false,false,false,false,false,false]`
+	var given string = "[" + strings.Repeat(givenRow+",", 8) + givenRow + "]"
+	err = json.Unmarshal([]byte(`{"version":1,"grid":`+grid+`,"given":`+given+`}`), game)
+	assert.NotNil(t, err)
+}
+
+func Test_SolveResult(t *testing.T) {
+	puzzle, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	solution, err := ParseGame(easyPuzzleSolution)
+	assert.Nil(t, err)
+
+	var result *SolveResult = NewSolveResult(puzzle, solution, &GamePlayStatistics{Iterations: 52}, nil)
+	data, err := json.Marshal(result)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"statistics":{"backTracks":0,"iterations":52`)
+
+	var decoded *SolveResult = &SolveResult{}
+	err = json.Unmarshal(data, decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, solution.Grid, decoded.Solution.Grid)
+	assert.Equal(t, puzzle.Grid, decoded.Puzzle.Grid)
+	assert.Equal(t, 52, decoded.Statistics.Iterations)
+
+	result = NewSolveResult(puzzle, nil, &GamePlayStatistics{}, errors.New("no solution"))
+	data, err = json.Marshal(result)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "solution\":")
+	assert.Contains(t, string(data), `"error":"no solution"`)
+
+	err = json.Unmarshal([]byte(`{"version":3}`), decoded)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+)
+
+/*
+logger returns the Logger of the solver, nil when it does not log.
+*/
+func (solver *Solver) logger() *slog.Logger {
+	if solver.Logger != nil {
+		return solver.Logger
+	}
+	if solver.Log {
+		return slog.Default()
+	}
+
+	return nil
+}
+
+/*
+newLogObserver returns an Observer that logs the progress and solutions of a search to logger at
+debug level, leaving out the placements and back tracks.  It returns nil when logger is nil or does
+not log debug messages, so a search costs nothing extra.
+*/
+func newLogObserver(logger *slog.Logger) Observer {
+	if logger == nil || !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return nil
+	}
+
+	return ObserverFunc(func(event Event) {
+		switch event.Kind {
+		case EventProgress:
+			logger.Debug("search progress",
+				slog.Int("iteration", event.Iteration),
+				slog.Int("depth", event.Depth),
+				slog.Int("setCount", event.SetCount),
+				slog.Int("backTracks", event.BackTracks),
+				slog.Any("remaining", event.Remaining))
+		case EventSolution:
+			logger.Debug("solution found",
+				slog.Int("iteration", event.Iteration),
+				slog.Int("depth", event.Depth))
+		}
+	})
+}
+
+/*
+logSolve logs the outcome of a solve at info level, or at warn level when it failed.
+*/
+func (solver *Solver) logSolve(ctx context.Context, statistics *GamePlayStatistics, err error) {
+	var logger *slog.Logger = solver.logger()
+	if logger == nil {
+		return
+	}
+
+	var attrs []slog.Attr = []slog.Attr{
+		slog.Int("iterations", statistics.Iterations),
+		slog.Int("backTracks", statistics.BackTracks),
+		slog.Int("maxDepth", statistics.MaxDepth),
+		slog.Duration("wallTime", statistics.WallTime),
+		slog.Int64("seed", statistics.Seed),
+	}
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "solve failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "solve finished", attrs...)
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolver_Logger(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	var solver *Solver = CreateSolver()
+	solver.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	solver.IterationReportInterval = 10
+
+	_, stats, err := solver.Solve(game)
+	assert.Nil(t, err)
+
+	var lines []string = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, stats.Iterations/10+2)
+	assert.Contains(t, lines[0], "level=DEBUG msg=\"search progress\" iteration=10 depth=")
+	assert.Contains(t, lines[0], "setCount=")
+	assert.Contains(t, lines[len(lines)-2], "level=DEBUG msg=\"solution found\"")
+	assert.Contains(t, lines[len(lines)-1], "level=INFO msg=\"solve finished\"")
+	assert.Contains(t, lines[len(lines)-1], "backTracks=")
+
+	/*
+		At info level only the outcome is logged, and the search runs without an observer.
+	*/
+	buf.Reset()
+	solver.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	assert.Nil(t, solver.observer())
+	_, _, err = solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), "msg=\"solve finished\"")
+
+	buf.Reset()
+	_, _, err = solver.Solve(mustParse(t, "12345678."+strings.Repeat(".", 71)+"9"))
+	assert.ErrorIs(t, err, ErrNoSolution)
+	assert.Contains(t, buf.String(), "level=WARN msg=\"solve failed\"")
+	assert.Contains(t, buf.String(), "error=\"puzzle has no solution\"")
+}
+
+func TestSolver_logger(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	assert.Nil(t, solver.logger())
+
+	solver.Log = true
+	assert.Equal(t, slog.Default(), solver.logger())
+
+	var logger *slog.Logger = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	solver.Logger = logger
+	assert.Equal(t, logger, solver.logger())
+}
+
+func mustParse(t *testing.T, puzzle string) *Game {
+	game, err := ParseGame(puzzle)
+	assert.Nil(t, err)
+	return game
+}
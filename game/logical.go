@@ -0,0 +1,307 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+LogicalSolver solves a puzzle the way a person would, without guessing: it keeps the candidates of
+every empty cell and applies its Techniques, easiest first, starting over from the easiest after
+every step.  Each step is recorded, so the trace shows how the puzzle can be solved by hand.
+*/
+type LogicalSolver struct {
+	/*
+		Techniques are tried in the order given; DefaultTechniques is used when it is nil.
+	*/
+	Techniques []Technique
+	/*
+		SkipUniqueness leaves out the techniques that rely on the puzzle having exactly one solution,
+		for puzzles where that is not known.
+	*/
+	SkipUniqueness bool
+}
+
+/*
+Technique is a named pattern of candidates that proves values can be placed or candidates removed.
+Difficulty ranks techniques by how hard they are to spot, on the scale of Sudoku Explainer ratings
+times ten.  RequiresUniqueness marks techniques that are only sound when the puzzle has exactly
+one solution.
+*/
+type Technique struct {
+	Name               string
+	Difficulty         int
+	RequiresUniqueness bool
+	find               func(grid *candidateGrid) *Step
+}
+
+/*
+CellDigit is a digit, 1 through 9, in a cell.
+*/
+type CellDigit struct {
+	Cell  Cell
+	Digit int
+}
+
+func (cd CellDigit) String() string {
+	return fmt.Sprintf("%d at r%dc%d", cd.Digit, cd.Cell.Row+1, cd.Cell.Column+1)
+}
+
+/*
+Step is one deduction of a LogicalSolver.  Cells are the cells of the pattern the technique found
+and Digits the digits it is about; Placements are the values it proves and Eliminations the
+candidates it removes.  Chain holds the links of the chain or coloring behind the step, if any.
+*/
+type Step struct {
+	Technique    string
+	Difficulty   int
+	Cells        []Cell
+	Digits       []int
+	Placements   []CellDigit
+	Eliminations []CellDigit
+	Chain        []ChainLink
+}
+
+func (step Step) String() string {
+	var buf strings.Builder
+	buf.WriteString(step.Technique)
+	if len(step.Digits) > 0 {
+		buf.WriteString(fmt.Sprintf(" on %v", step.Digits))
+	}
+	if len(step.Cells) > 0 {
+		buf.WriteString(" in " + cellNames(step.Cells))
+	}
+	if len(step.Chain) > 0 {
+		buf.WriteString(": " + chainString(step.Chain))
+	}
+	for _, placement := range step.Placements {
+		buf.WriteString(fmt.Sprintf(", places %s", placement))
+	}
+	for _, elimination := range step.Eliminations {
+		buf.WriteString(fmt.Sprintf(", removes %s", elimination))
+	}
+
+	return buf.String()
+}
+
+/*
+ErrStuck is returned when none of the techniques of a LogicalSolver make progress on the board.
+*/
+var ErrStuck = errors.New("no technique applies")
+
+/*
+Solve applies techniques to game until it is solved.  It returns the board as far as it got and
+the steps taken.  The error is nil when the board is solved, ErrStuck when no technique applies,
+ErrNoSolution when the candidates run into a contradiction, and the conflicts of the board, see
+ValidateGame, when game breaks the rules to begin with.
+*/
+func (ls *LogicalSolver) Solve(game *Game) (*Game, []Step, error) {
+	grid, err := newCandidateGrid(game)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var steps []Step = make([]Step, 0)
+	for !grid.solved() {
+		if !grid.consistent() {
+			return grid.toGame(game), steps, ErrNoSolution
+		}
+		var step *Step = ls.next(grid)
+		if step == nil {
+			return grid.toGame(game), steps, ErrStuck
+		}
+		grid.apply(step)
+		steps = append(steps, *step)
+	}
+
+	return grid.toGame(game), steps, nil
+}
+
+/*
+next returns the first step of the easiest technique that makes progress, nil when none does.
+*/
+func (ls *LogicalSolver) next(grid *candidateGrid) *Step {
+	var techniques []Technique = ls.Techniques
+	if techniques == nil {
+		techniques = DefaultTechniques()
+	}
+
+	for _, technique := range techniques {
+		if technique.RequiresUniqueness && ls.SkipUniqueness {
+			continue
+		}
+		if step := technique.find(grid); step != nil {
+			step.Technique = technique.Name
+			step.Difficulty = technique.Difficulty
+			return step
+		}
+	}
+
+	return nil
+}
+
+/*
+candidateGrid is a board with the candidates of its empty cells, which techniques remove as they
+go, unlike gameState where they follow from the placed values alone.
+*/
+type candidateGrid struct {
+	values     [numRows][numColumns]int
+	candidates [numRows][numColumns]digitSet
+	given      [numRows][numColumns]bool
+}
+
+func newCandidateGrid(game *Game) (*candidateGrid, error) {
+	if game == nil {
+		return nil, errors.New("game is nil on call to Solve.")
+	}
+	gs, err := createGame(game)
+	if err != nil {
+		return nil, err
+	}
+
+	var grid *candidateGrid = &candidateGrid{}
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			grid.values[row][column] = gs.Grid[row][column]
+			grid.candidates[row][column] = gs.cellCandidates(row, column)
+			grid.given[row][column] = game.IsGiven(row, column)
+		}
+	}
+
+	return grid, nil
+}
+
+func (grid *candidateGrid) get(cell Cell) digitSet {
+	return grid.candidates[cell.Row][cell.Column]
+}
+
+func (grid *candidateGrid) empty(cell Cell) bool {
+	return grid.values[cell.Row][cell.Column] == NotSet
+}
+
+/*
+place sets value in cell and takes it out of the candidates of the cell's peers.
+*/
+func (grid *candidateGrid) place(cell Cell, value int) {
+	grid.values[cell.Row][cell.Column] = value
+	grid.candidates[cell.Row][cell.Column] = 0
+	for _, peer := range peers[cell.Row*numColumns+cell.Column] {
+		grid.candidates[peer.Row][peer.Column] = grid.candidates[peer.Row][peer.Column].remove(value)
+	}
+}
+
+func (grid *candidateGrid) apply(step *Step) {
+	for _, placement := range step.Placements {
+		grid.place(placement.Cell, placement.Digit-1)
+	}
+	for _, elimination := range step.Eliminations {
+		var cell Cell = elimination.Cell
+		grid.candidates[cell.Row][cell.Column] = grid.candidates[cell.Row][cell.Column].remove(elimination.Digit - 1)
+	}
+}
+
+func (grid *candidateGrid) solved() bool {
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			if grid.values[row][column] == NotSet {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+/*
+consistent reports whether every empty cell has a candidate and every value missing from a unit
+has a cell left to go in.
+*/
+func (grid *candidateGrid) consistent() bool {
+	for unit := 0; unit < numUnits; unit++ {
+		var placed, possible digitSet
+		for _, cell := range units[unit] {
+			if !grid.empty(cell) {
+				placed = placed.add(grid.values[cell.Row][cell.Column])
+				continue
+			}
+			if grid.get(cell) == 0 {
+				return false
+			}
+			possible |= grid.get(cell)
+		}
+		if placed|possible != allDigits {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+cellsWith returns the empty cells of unit that still have value as a candidate.
+*/
+func (grid *candidateGrid) cellsWith(unit int, value int) []Cell {
+	var ret []Cell = make([]Cell, 0, numCandidates)
+	for _, cell := range units[unit] {
+		if grid.get(cell).has(value) {
+			ret = append(ret, cell)
+		}
+	}
+
+	return ret
+}
+
+/*
+toGame returns the board, with the givens of game, the puzzle it started from.
+*/
+func (grid *candidateGrid) toGame(game *Game) *Game {
+	var ret *Game = NewGame()
+	ret.Given = make([][]bool, numRows)
+	for row := 0; row < numRows; row++ {
+		ret.Given[row] = make([]bool, numColumns)
+		for column := 0; column < numColumns; column++ {
+			ret.Grid[row][column] = grid.values[row][column]
+			ret.Given[row][column] = game.IsGiven(row, column)
+		}
+	}
+
+	return ret
+}
+
+/*
+eliminations lists value for each of cells that still has it as a candidate.
+*/
+func (grid *candidateGrid) eliminations(value int, cells []Cell) []CellDigit {
+	var ret []CellDigit
+	for _, cell := range cells {
+		if grid.get(cell).has(value) {
+			ret = append(ret, CellDigit{Cell: cell, Digit: value + 1})
+		}
+	}
+
+	return ret
+}
+
+/*
+sees reports whether two different cells share a row, column or sub grid.
+*/
+func sees(a, b Cell) bool {
+	if a == b {
+		return false
+	}
+
+	return a.Row == b.Row || a.Column == b.Column || subGridIndex(a.Row, a.Column) == subGridIndex(b.Row, b.Column)
+}
+
+/*
+digits turns a set of values into the digits 1 through 9.
+*/
+func digits(set digitSet) []int {
+	var ret []int = set.values()
+	for i := range ret {
+		ret[i]++
+	}
+
+	return ret
+}
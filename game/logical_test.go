@@ -0,0 +1,130 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalSolver_Solve(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	var solver *LogicalSolver = &LogicalSolver{}
+	solution, steps, err := solver.Solve(game)
+	assert.Nil(t, err)
+	assert.Equal(t, easyPuzzleSolution, FormatLine(solution))
+	assert.True(t, solution.IsGiven(0, 0))
+	assert.False(t, solution.IsGiven(0, 2))
+	assert.Len(t, steps, 81-30)
+	for _, step := range steps {
+		assert.Contains(t, []string{HiddenSingle.Name, NakedSingle.Name}, step.Technique)
+		assert.Len(t, step.Placements, 1)
+	}
+
+	/*
+		Without singles nothing gets placed, and the solver stops once the X-Wings run out.
+	*/
+	solver.Techniques = []Technique{XWing}
+	partial, steps, err := solver.Solve(game)
+	assert.ErrorIs(t, err, ErrStuck)
+	assert.Equal(t, easyPuzzle, FormatLine(partial))
+	assert.NotEmpty(t, steps)
+	for _, step := range steps {
+		assert.Equal(t, XWing.Name, step.Technique)
+		assert.Equal(t, XWing.Difficulty, step.Difficulty)
+		assert.Empty(t, step.Placements)
+		assert.NotEmpty(t, step.Eliminations)
+	}
+}
+
+func TestLogicalSolver_Solve_sound(t *testing.T) {
+	/*
+		Every placement has to agree with the solution and no elimination may remove it.  The puzzles
+		after the first two are solved by hand with the technique given.
+	*/
+	for _, test := range []struct {
+		puzzle    string
+		technique string
+	}{
+		{easyPuzzle, ""},
+		{hardPuzzle, ""},
+		{"9.1.8.......23.7......4..8........97..45...6..1.7....827..1.6...9.....1.......2..", LockedCandidatesPointing.Name},
+		{"..52..8...74.....1.1.5......5..7........8.42.46......9.......63....29..87...6...2", LockedCandidatesClaiming.Name},
+		{"...8....7..1..3...57..9.3..1.....5...9...84.3...72.69..4.9...............354.2.8.", NakedPair.Name},
+		{".6.978...8.........756......5..3...4..1...37....1..2....2.89..76..34..8..1.......", XWing.Name},
+		{"..8....1.4.67....27..9..8.6...34..59..5.......3......7..4.1.2.....263.........9.8", NakedTriple.Name},
+		{"4..8....56..5.....8.....19...7.9..8....64.7..5..7....3.51......7....4...3.2...6.7", Swordfish.Name},
+		{".7.5.6..4...4...2..9.......9.....3.8.3.78...9.47.1.......8...61..51..8.......74..", HiddenTriple.Name},
+		{"9.1.8.......23.7......4..8........97..45...6..1.7....827..1.6...9.....1.......2..", XYWing.Name},
+		{"36..7...9..23.5...7.....8..875...6..2..1...3..9......59..6.8.......24.8..........", XYZWing.Name},
+	} {
+		game, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+		solution, _, err := CreateSolver().Solve(game)
+		assert.Nil(t, err)
+		var expected string = FormatLine(solution)
+
+		partial, steps, err := (&LogicalSolver{}).Solve(game)
+		var used map[string]bool = make(map[string]bool)
+		if test.technique != "" {
+			assert.Nil(t, err, test.puzzle)
+		} else {
+			assert.True(t, err == nil || err == ErrStuck, test.puzzle)
+		}
+		for _, step := range steps {
+			used[step.Technique] = true
+			for _, placement := range step.Placements {
+				assert.Equal(t, expected[placement.Cell.Row*numColumns+placement.Cell.Column],
+					byte('0'+placement.Digit), step.String())
+			}
+			for _, elimination := range step.Eliminations {
+				assert.NotEqual(t, expected[elimination.Cell.Row*numColumns+elimination.Cell.Column],
+					byte('0'+elimination.Digit), step.String())
+			}
+		}
+		for i, ch := range FormatLine(partial) {
+			if ch != '.' {
+				assert.Equal(t, expected[i], byte(ch))
+			}
+		}
+		if test.technique != "" {
+			assert.True(t, used[test.technique], test.technique)
+		}
+	}
+}
+
+func TestLogicalSolver_Solve_errors(t *testing.T) {
+	var solver *LogicalSolver = &LogicalSolver{}
+
+	_, _, err := solver.Solve(nil)
+	assert.NotNil(t, err)
+
+	game, err := ParseGame("12345678." + strings.Repeat(".", 71) + "9")
+	assert.Nil(t, err)
+	partial, _, err := solver.Solve(game)
+	assert.ErrorIs(t, err, ErrNoSolution)
+	assert.NotNil(t, partial)
+
+	game, err = ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	game.Grid[0][2] = 4
+	_, _, err = solver.Solve(game)
+	var conflict ConflictError
+	assert.True(t, errors.As(err, &conflict))
+}
+
+func TestStep_String(t *testing.T) {
+	var step Step = Step{
+		Technique:    XWing.Name,
+		Cells:        []Cell{{Row: 1, Column: 2}, {Row: 5, Column: 7}},
+		Digits:       []int{4},
+		Eliminations: []CellDigit{{Cell: Cell{Row: 0, Column: 2}, Digit: 4}},
+	}
+	assert.Equal(t, "X-Wing on [4] in r2c3 r6c8, removes 4 at r1c3", step.String())
+
+	step = Step{Technique: NakedSingle.Name, Placements: []CellDigit{{Cell: Cell{Row: 8, Column: 8}, Digit: 9}}}
+	assert.Equal(t, "Naked Single, places 9 at r9c9", step.String())
+}
@@ -1,13 +1,10 @@
 package game
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"log"
 	"math/rand"
-	"os"
-	"sync"
 	"time"
 	"encoding/json"
 
@@ -21,12 +18,6 @@ const NotSet = -1
 const DefaultMaxIterations = 1000000000
 const CheckChildrenDepthThreshold int = 50
 
-func init() {
-	rand.Seed(time.Now().Unix())
-
-	log.SetOutput(os.Stdout)
-}
-
 type candidate struct {
 	value  int
 	row    int
@@ -49,6 +40,14 @@ func (c *candidate) equals(other *candidate) bool {
 	return c.row == other.row && c.column == other.column && c.value == other.value
 }
 
+/*
+Cell is the position of a square on the board, both coordinates starting at 0.
+*/
+type Cell struct {
+	Row    int
+	Column int
+}
+
 /*
 Sub Grids
 0|1|2
@@ -58,28 +57,89 @@ Sub Grids
 
 type Game struct {
 	Grid [][]int
+	/*
+		Given marks the cells of Grid that belong to the puzzle, as opposed to cells filled in by a
+		player or the solver.  A nil Given means every set cell is a given.
+	*/
+	Given [][]bool
 }
 
-func gridToString(grid [][]int) string {
-	var buf bytes.Buffer
-	buf.WriteString("\n")
-	for row := 0; row < numRows; row++ {
-		for column := 0; column < numColumns; column++ {
-			var value int = grid[row][column]
-			if value == NotSet {
-				buf.WriteString("-")
-			} else {
-				buf.WriteString(fmt.Sprintf("%d", value))
+func (gs *Game) IsGiven(row, column int) bool {
+	if gs.Grid[row][column] == NotSet {
+		return false
+	}
+	if gs.Given == nil {
+		return true
+	}
+
+	return gs.Given[row][column]
+}
+
+/*
+Candidates returns the values that could be placed in an empty cell without repeating a value in
+its row, column or sub grid.  A set cell has no candidates.
+*/
+func (gs *Game) Candidates(row, column int) []int {
+	var ret []int = make([]int, 0, numCandidates)
+	if gs.Grid[row][column] != NotSet {
+		return ret
+	}
+
+	var used [numCandidates]bool
+	var minRow, maxRow int = snapToGrid(row)
+	var minColumn, maxColumn int = snapToGrid(column)
+	for n := 0; n < numColumns; n++ {
+		if value := gs.Grid[row][n]; value != NotSet {
+			used[value] = true
+		}
+		if value := gs.Grid[n][column]; value != NotSet {
+			used[value] = true
+		}
+	}
+	for r := minRow; r < maxRow; r++ {
+		for c := minColumn; c < maxColumn; c++ {
+			if value := gs.Grid[r][c]; value != NotSet {
+				used[value] = true
 			}
+		}
+	}
+
+	for value := 0; value < numCandidates; value++ {
+		if !used[value] {
+			ret = append(ret, value)
+		}
+	}
+
+	return ret
+}
 
-			if column != numColumns-1 {
-				buf.WriteString("|")
+/*
+Givens returns a new game holding only the given cells of gs.
+*/
+func (gs *Game) Givens() *Game {
+	var ret *Game = NewGame()
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			if gs.IsGiven(row, column) {
+				ret.Grid[row][column] = gs.Grid[row][column]
 			}
 		}
-		buf.WriteString("\n")
 	}
 
-	return buf.String()
+	return ret
+}
+
+/*
+gridToString draws grid as ASCII text for logging.  A grid WriteText cannot draw is printed as a
+plain slice instead.
+*/
+func gridToString(grid [][]int) string {
+	text, err := FormatText(&Game{Grid: grid}, &TextOptions{ASCII: true})
+	if err != nil {
+		return fmt.Sprintf("\n%v\n", grid)
+	}
+
+	return "\n" + text
 }
 
 func (gs *Game) String() string {
@@ -101,9 +161,53 @@ func NewGame() *Game {
 	return game
 }
 
+/*
+GamePlayStatistics describes the work a search did.  Depths are counted in placements made on top
+of the puzzle, whether by branching or by propagation.
+*/
 type GamePlayStatistics struct {
-	BackTracks int
-	Iterations int
+	/*
+		BackTracks counts the placements taken back.
+	*/
+	BackTracks int `json:"backTracks"`
+	Iterations int `json:"iterations"`
+	/*
+		Propagations counts the values placed by constraint propagation rather than by a search step.
+	*/
+	Propagations int `json:"propagations"`
+	/*
+		Seed is the seed the search drew its random choices from, see Solver.Seed.  It is 0, unknown,
+		when the choices came from Solver.Rand.
+	*/
+	Seed int64 `json:"seed"`
+	/*
+		MaxDepth is the most placements the search had on the board at once.
+	*/
+	MaxDepth int `json:"maxDepth"`
+	/*
+		NodesExpanded counts the boards the search branched from.
+	*/
+	NodesExpanded int `json:"nodesExpanded"`
+	/*
+		CandidatesEvaluated counts the branches the search went down, each a candidate placed on
+		the board to see where it leads.
+	*/
+	CandidatesEvaluated int `json:"candidatesEvaluated"`
+	/*
+		WallTime is how long the search took, in nanoseconds in JSON.
+	*/
+	WallTime time.Duration `json:"wallTime"`
+	/*
+		Depths holds, for each depth, how many boards were expanded there and how many branches they
+		had between them.  Branches over Nodes is the branching factor at that depth.
+	*/
+	Depths []DepthStatistics `json:"depths,omitempty"`
+	/*
+		SnapShots is the time series recorded every Solver.SnapShotInterval iterations.
+	*/
+	SnapShots []SnapShot `json:"snapShots,omitempty"`
+
+	started time.Time
 }
 
 type gameState struct {
@@ -111,6 +215,28 @@ type gameState struct {
 	initialGameState *Game
 	moves              candidateList
 	GamePlayStatistics *GamePlayStatistics
+	/*
+		The values used in each row, column and sub grid.  addCandidate and removeCandidate keep them
+		in step with Grid so the candidates of a cell can be read off without scanning the board.
+		Code that writes Grid directly has to call rebuildMasks afterwards.
+	*/
+	rowMasks      [numRows]digitSet
+	columnMasks   [numColumns]digitSet
+	subGridMasks  [numSubSquares]digitSet
+	rowCounts     [numRows][numCandidates]int8
+	columnCounts  [numColumns][numCandidates]int8
+	subGridCounts [numSubSquares][numCandidates]int8
+	/*
+		random is the source of the random choices made while searching from this board, the global
+		source when nil.
+	*/
+	random *rand.Rand
+	/*
+		best is a copy of Grid from the deepest point a search has reached, bestDepth the number of
+		moves made to get there, see keepBest.
+	*/
+	best      [][]int
+	bestDepth int
 }
 
 func (gs *gameState) isMutable(can *candidate) bool {
@@ -130,6 +256,12 @@ func (gs *gameState) clone() *gameState {
 	var ret *gameState = &gameState{
 		Grid: make([][]int, numRows),
 		initialGameState: gs.initialGameState,
+		rowMasks: gs.rowMasks,
+		columnMasks: gs.columnMasks,
+		subGridMasks: gs.subGridMasks,
+		rowCounts: gs.rowCounts,
+		columnCounts: gs.columnCounts,
+		subGridCounts: gs.subGridCounts,
 	}
 
 
@@ -143,29 +275,163 @@ func (gs *gameState) clone() *gameState {
 	return ret
 }
 
+/*
+toGame copies the grid of gs into a new Game whose givens are the set cells of the initial game.
+*/
+func (gs *gameState) toGame() *Game {
+	var g *Game = NewGame()
+	g.Given = make([][]bool, numRows)
+	for row := 0; row < numRows; row++ {
+		g.Given[row] = make([]bool, numColumns)
+		for column := 0; column < numColumns; column++ {
+			g.Grid[row][column] = gs.Grid[row][column]
+			g.Given[row][column] = gs.initialGameState.IsGiven(row, column)
+		}
+	}
+
+	return g
+}
+
+/*
+keepBest copies Grid aside when depth, the number of moves a search has made on gs, is the deepest
+it has reached.  A search only gets deeper one move at a time, so it copies at most once per cell.
+*/
+func (gs *gameState) keepBest(depth int) {
+	if gs.best != nil && depth <= gs.bestDepth {
+		return
+	}
+	if gs.best == nil {
+		gs.best = make([][]int, numRows)
+		for row := 0; row < numRows; row++ {
+			gs.best[row] = make([]int, numColumns)
+		}
+	}
+	for row := 0; row < numRows; row++ {
+		copy(gs.best[row], gs.Grid[row])
+	}
+	gs.bestDepth = depth
+}
+
+/*
+bestGame is toGame for the board kept by keepBest, the current board when none was kept.
+*/
+func (gs *gameState) bestGame() *Game {
+	var ret *Game = gs.toGame()
+	if gs.best != nil {
+		for row := 0; row < numRows; row++ {
+			copy(ret.Grid[row], gs.best[row])
+		}
+	}
+
+	return ret
+}
+
 func resetGameState(gs *gameState) {
 	for row := 0; row < numRows; row++ {
 		for column := 0; column < numColumns; column++ {
 			gs.Grid[row][column] = NotSet
 		}
 	}
+	gs.rebuildMasks()
+}
+
+/*
+rebuildMasks recomputes the row, column and sub grid masks from Grid.
+*/
+func (gs *gameState) rebuildMasks() {
+	gs.rowCounts = [numRows][numCandidates]int8{}
+	gs.columnCounts = [numColumns][numCandidates]int8{}
+	gs.subGridCounts = [numSubSquares][numCandidates]int8{}
+	gs.rowMasks = [numRows]digitSet{}
+	gs.columnMasks = [numColumns]digitSet{}
+	gs.subGridMasks = [numSubSquares]digitSet{}
+
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			if value := gs.Grid[row][column]; value != NotSet {
+				gs.count(row, column, value)
+			}
+		}
+	}
+}
+
+/*
+cellCandidates returns the values that can go in a cell without repeating a value in its row,
+column or sub grid.  A set cell has no candidates.
+*/
+func (gs *gameState) cellCandidates(row, column int) digitSet {
+	if gs.Grid[row][column] != NotSet {
+		return 0
+	}
+
+	return allDigits &^ (gs.rowMasks[row] | gs.columnMasks[column] | gs.subGridMasks[subGridIndex(row, column)])
+}
+
+/*
+canPlace reports whether c goes in an empty cell without breaking a constraint.  It gives the same
+answer as adding c and running validateGameState on a valid board, without the board scans.
+*/
+func (gs *gameState) canPlace(c *candidate) bool {
+	return gs.cellCandidates(c.row, c.column).has(c.value)
 }
 
 func (gs *gameState) String() string {
 	return gridToString(gs.Grid)
 }
 
+/*
+Json encodes the current grid using the schema described by JSONVersion.
+*/
 func (gs *gameState) Json() string {
-	jsonBytes, _ := json.MarshalIndent(gs, "", "\t")
+	jsonBytes, _ := json.MarshalIndent(gs.toGame(), "", "\t")
 	return string(jsonBytes)
 }
 
 func (gs *gameState) addCandidate(candidate *candidate) {
-	gs.Grid[candidate.row][candidate.column] = candidate.value
+	var row, column int = candidate.row, candidate.column
+	if previous := gs.Grid[row][column]; previous != NotSet {
+		gs.uncount(row, column, previous)
+	}
+	gs.Grid[row][column] = candidate.value
+	gs.count(row, column, candidate.value)
 }
 
 func (gs *gameState) removeCandidate(candidate *candidate) {
-	gs.Grid[candidate.row][candidate.column] = NotSet
+	var row, column int = candidate.row, candidate.column
+	if previous := gs.Grid[row][column]; previous != NotSet {
+		gs.uncount(row, column, previous)
+	}
+	gs.Grid[row][column] = NotSet
+}
+
+func (gs *gameState) count(row, column, value int) {
+	var subSquare int = subGridIndex(row, column)
+	gs.rowCounts[row][value]++
+	gs.columnCounts[column][value]++
+	gs.subGridCounts[subSquare][value]++
+	gs.rowMasks[row] = gs.rowMasks[row].add(value)
+	gs.columnMasks[column] = gs.columnMasks[column].add(value)
+	gs.subGridMasks[subSquare] = gs.subGridMasks[subSquare].add(value)
+}
+
+/*
+uncount clears a value from the masks of a unit only once no cell of the unit holds it, so a board
+with duplicates still ends up with the right masks when one of them is removed.
+*/
+func (gs *gameState) uncount(row, column, value int) {
+	var subSquare int = subGridIndex(row, column)
+	gs.rowCounts[row][value]--
+	gs.columnCounts[column][value]--
+	gs.subGridCounts[subSquare][value]--
+	if gs.rowCounts[row][value] == 0 {
+		gs.rowMasks[row] = gs.rowMasks[row].remove(value)
+	}
+	if gs.columnCounts[column][value] == 0 {
+		gs.columnMasks[column] = gs.columnMasks[column].remove(value)
+	}
+	if gs.subGridCounts[subSquare][value] == 0 {
+		gs.subGridMasks[subSquare] = gs.subGridMasks[subSquare].remove(value)
+	}
 }
 
 func (gs *gameState) setCount() int {
@@ -289,164 +555,45 @@ func computeBaseColumn(subSquare int) (int, error) {
 	return subSquare, nil
 }
 
+/*
+validateSubGrid returns the conflicts in a sub grid as ConflictErrors joined into one error.
+*/
 func validateSubGrid(gameState *gameState, subSquare int) (err error) {
-	var baseRow, baseColumn int
-	baseRow, err = computeBaseRow(subSquare)
-	if err != nil {
-		return err
-	}
-	baseColumn, err = computeBaseColumn(subSquare)
-	if err != nil {
-		return err
-	}
-
-	maxRow := baseRow + 3
-	maxColumn := baseColumn + 3
-
-	var valueCounts map[int]int = make(map[int]int)
-	var values []int = make([]int, 0)
-	var errorValues []int = make([]int, 0)
-	var valid bool = true
-	var value int = NotSet
-
-	for row := baseRow; row < maxRow; row++ {
-		for column := baseColumn; column < maxColumn; column++ {
-			value = gameState.Grid[row][column]
-			if value != NotSet {
-				count, ok := valueCounts[value]
-				if !ok {
-					values = append(values, value)
-					count = 0
-				}
-
-				valueCounts[value] = count + 1
-			}
-		}
-	}
-
-	for _, value := range values {
-		count, _ := valueCounts[value]
-		if count > 1 || count == 0 {
-			valid = false
-			errorValues = append(errorValues, value)
-		}
-	}
-
-	if !valid {
-		return errors.New(fmt.Sprintf("the following values are too represented in the sub grid %d: %v",
-			subSquare,
-			errorValues))
-	} else {
-		return nil
+	if subSquare < 0 || subSquare >= numSubSquares {
+		return errors.New(fmt.Sprintf("INVALID SUB SQUARE: %d", subSquare))
 	}
 
+	return conflictsError(unitConflicts(gameState.Grid, numRows+numColumns+subSquare))
 }
 
+/*
+validateRow returns the conflicts in a row as ConflictErrors joined into one error.
+*/
 func validateRow(gameState *gameState, row int) (err error) {
 	if row < 0 || row >= numRows {
 		return errors.New(fmt.Sprintf("row passed into validate row is not valid: %d", row))
 	}
 
-	var valueCounts map[int]int = make(map[int]int)
-	var values []int = make([]int, 0)
-	var errorValues []int = make([]int, 0)
-	var valid bool = true
-	var value int = NotSet
-
-	for n := 0; n < numColumns; n++ {
-		value = gameState.Grid[row][n]
-		if value != -1 {
-			count, ok := valueCounts[value]
-			if !ok {
-				values = append(values, value)
-				count = 0
-			}
-
-			valueCounts[value] = count + 1
-		}
-	}
-
-	for _, val := range values {
-		if valueCounts[val] != 1 {
-			valid = false
-			errorValues = append(errorValues, val)
-		}
-	}
-
-	if !valid {
-		return errors.New(fmt.Sprintf("Row %d has the following duplicated values: %v", row, errorValues))
-	} else {
-		return nil
-	}
-
+	return conflictsError(unitConflicts(gameState.Grid, row))
 }
 
+/*
+validateColumn returns the conflicts in a column as ConflictErrors joined into one error.
+*/
 func validateColumn(gameState *gameState, column int) (err error) {
-	if column < 0 || column >= numRows {
+	if column < 0 || column >= numColumns {
 		return errors.New(fmt.Sprintf("column passed into validate column is not valid: %d", column))
 	}
 
-	var valueCounts map[int]int = make(map[int]int)
-	var values []int = make([]int, 0)
-	var errorValues []int = make([]int, 0)
-	var valid bool = true
-	var value int = NotSet
-
-	for n := 0; n < numRows; n++ {
-		value = gameState.Grid[n][column]
-		if value != NotSet {
-			count, ok := valueCounts[value]
-			if !ok {
-				values = append(values, value)
-				count = 0
-			}
-
-			valueCounts[value] = count + 1
-		}
-	}
-
-	for _, val := range values {
-		if valueCounts[val] != 1 {
-			valid = false
-			errorValues = append(errorValues, val)
-		}
-	}
-
-	if !valid {
-		return errors.New(fmt.Sprintf("Column %d has the following duplicated values: %v", column, errorValues))
-	} else {
-		return nil
-	}
-
+	return conflictsError(unitConflicts(gameState.Grid, numRows+column))
 }
 
+/*
+validateGameState returns every conflict on the board, see ValidateGame, as ConflictErrors joined
+into one error.
+*/
 func validateGameState(gs *gameState) (err error) {
-	/*Validate Sub Grids*/
-
-	for n := 0; n < numSubSquares; n++ {
-		err = validateSubGrid(gs, n)
-		if err != nil {
-			return err
-		}
-	}
-
-	/*Validate Rows*/
-	for n := 0; n < numRows; n++ {
-		err = validateRow(gs, n)
-		if err != nil {
-			return err
-		}
-	}
-
-	/*Validate Columns*/
-	for n := 0; n < numColumns; n++ {
-		err = validateColumn(gs, n)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return conflictsError(ValidateGame(&Game{Grid: gs.Grid}))
 }
 
 func isFinished(gs *gameState) bool {
@@ -481,9 +628,14 @@ func createGame(game *Game) (*gameState, error) {
 	for row := 0; row < numRows; row++ {
 		gs.Grid[row] = make([]int, numColumns)
 		for column := 0; column < numColumns; column++ {
-			gs.Grid[row][column] = game.Grid[row][column]
+			var value int = game.Grid[row][column]
+			if value < NotSet || value >= numCandidates {
+				return nil, errors.New(fmt.Sprintf("row %d, column %d holds invalid value %d", row, column, value))
+			}
+			gs.Grid[row][column] = value
 		}
 	}
+	gs.rebuildMasks()
 
 	err = validateGameState(gs)
 	if err != nil {
@@ -538,91 +690,84 @@ func createAllCandidatesList() candidateList {
 	return ret
 }
 
+/*
+createValidCandidateListAsync is createValidCandidateList with the checks spread over a worker
+pool, which is stopped before it returns.
+*/
 func createValidCandidateListAsync(gs *gameState, allCandidate candidateList, checkChild bool) candidateList {
-	var wait sync.WaitGroup
-	wait.Add(len(allCandidate))
-	var candidateChannel chan *candidate = make(chan *candidate, len(allCandidate))
-	var validCandidates candidateList = make(candidateList, 0)
-
-	var doCheck = func(clone *gameState, c *candidate) {
-		defer wait.Done()
-		var toAdd *candidate
-		clone.addCandidate(c)
-		var err error = validateGameState(clone)
-		if err == nil {
-			if checkChild {
-				var childValidCandidates candidateList = createValidCandidateList(clone, allCandidate, false)
-				if len(childValidCandidates) >= clone.movesRemaining() {
-					toAdd = c
-				} else {
-					toAdd = nil
-				}
-			} else {
-				toAdd = c
-			}
-		} else {
-			toAdd = nil
-		}
-
-		candidateChannel <- toAdd
-	}
+	var pool *candidatePool = newCandidatePool(0)
+	defer pool.close()
 
-	for _, candidate := range allCandidate {
-		go doCheck(gs.clone(), candidate)
-	}
-
-	wait.Wait()
-
-	for i := 0; i < len(allCandidate); i++ {
-		var c *candidate
-		c = <-candidateChannel
-		if c != nil {
-			validCandidates = append(validCandidates, c)
-		}
-	}
-
-	return validCandidates
+	return pool.validCandidates(gs, allCandidate, checkChild)
 }
 
+/*
+createValidCandidateList returns the candidates that can be placed on the board as it stands.  The
+row, column and sub grid masks of gs answer this for each candidate in constant time.  With checkChild
+a candidate is only kept if, once placed, the board still has at least as many valid candidates as
+empty cells.
+*/
 func createValidCandidateList(gs *gameState, allCandidates candidateList, checkChild bool) candidateList {
 	var ret candidateList = make(candidateList, 0, len(allCandidates))
-	var err error
 	for _, c := range allCandidates {
-		if !gs.isSet(c.row, c.column) {
+		if !gs.canPlace(c) {
+			continue
+		}
+
+		if checkChild {
 			gs.addCandidate(c)
-			err = validateGameState(gs)
-			if err == nil {
-				if checkChild {
-					var childCandidates candidateList = createValidCandidateList(gs, allCandidates, false)
-					if len(childCandidates) >= gs.movesRemaining() {
-						ret = append(ret, c)
-					}
-				} else {
-					ret = append(ret, c)
-				}
-			} else {
-				//log.Printf("%s", err)
+			if countValidCandidates(gs, allCandidates) >= gs.movesRemaining() {
+				ret = append(ret, c)
 			}
 			gs.removeCandidate(c)
-
 		} else {
-			//log.Printf("skipping candidate for being already set: %v", *c)
+			ret = append(ret, c)
 		}
 	}
-	//log.Printf("returning %d candidates", len(ret))
+
 	return ret
 }
 
-func shuffleCandidates(candidates candidateList) {
-	length := len(candidates)
-	for i, c := range candidates {
-		j := rand.Int() % length
-		if j < 0 {
-			j = -j
+func countValidCandidates(gs *gameState, allCandidates candidateList) int {
+	var count int = 0
+	for _, c := range allCandidates {
+		if gs.canPlace(c) {
+			count++
 		}
-		candidates[i] = candidates[j]
-		candidates[j] = c
 	}
+
+	return count
+}
+
+func shuffleCandidates(candidates candidateList) {
+	shuffleCandidatesWith(nil, candidates)
+}
+
+/*
+shuffleCandidatesWith shuffles candidates with the numbers drawn from rng, or from the global
+source when rng is nil.
+*/
+func shuffleCandidatesWith(rng *rand.Rand, candidates candidateList) {
+	var intn func(int) int = rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+
+	for i := len(candidates) - 1; i > 0; i-- {
+		var j int = intn(i + 1)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+}
+
+/*
+intn draws from the random source of gs.
+*/
+func (gs *gameState) intn(n int) int {
+	if gs.random == nil {
+		return rand.Intn(n)
+	}
+
+	return gs.random.Intn(n)
 }
 
 type searchSnapShot struct {
@@ -676,78 +821,60 @@ func printGameState(gs *gameState) {
 	log.Print(gs.String())
 }
 
-func printTreeHistograms(tree searchTree, after int) {
-	var treeLen int = len(tree)
-	if treeLen < after {
-		return
-	} else {
-		var buf bytes.Buffer
-		for i := after; i < treeLen; i++ {
-			var candidates candidateList = tree[i]
-			var candidatesLen = len(candidates)
-			buf.WriteString(fmt.Sprintf("%d:%d, ", i, candidatesLen))
-		}
-
-		log.Print(buf.String())
-	}
-
-}
-
+/*
+PlayGame solves a puzzle with the original settings of the solver, see PlayGameStatistics, and
+returns the board it reached with the number of iterations it took.  As it always has, PlayGame
+returns a nil error when maxIterations cuts the search short.
+*/
 func PlayGame(initialGameState *Game, maxIterations int) (*Game, int, error) {
-	var gs *gameState
-	var err error
-
-	gs, err = createGame(initialGameState)
-	if err != nil {
-		return nil, NotSet, err
+	game, statistics, err := PlayGameStatistics(initialGameState, maxIterations)
+	if statistics == nil {
+		return game, NotSet, err
+	}
+	if err == ErrMaximumIterations {
+		err = nil
 	}
 
-	var allCandidates candidateList = createAllCandidatesList()
-	var tree searchTree = make(searchTree, 0, 81)
-	var moves candidateList = make(candidateList, 0, 81)
-	var playing bool = !isFinished(gs)
-	var iteration int = 0
-	var snapShotModulo int = 10000
-	var checkChildren bool = false
+	return game, statistics.Iterations, err
+}
 
-	for playing && (iteration < maxIterations) {
-		iteration++
-		if (iteration % snapShotModulo) == 0 {
-			log.Printf("iteration: %d", iteration)
-		}
-		if len(moves) >= CheckChildrenDepthThreshold {
-			checkChildren = true
-		} else {
-			checkChildren = false
-		}
+/*
+PlayGameStatistics solves a puzzle with the original settings of the solver, see playGameSolver.
+A search cut short by maxIterations returns the fullest board it reached with ErrMaximumIterations.
+Other settings can be compared through Solver.Solve, which returns the same statistics.
+*/
+func PlayGameStatistics(initialGameState *Game, maxIterations int) (*Game, *GamePlayStatistics, error) {
+	return playGameSolver(initialGameState, maxIterations).Solve(initialGameState)
+}
 
-		var candidates candidateList = createValidCandidateListAsync(gs, allCandidates, checkChildren)
-		shuffleCandidates(candidates)
-		if len(candidates) == 0 {
-			gs, moves, tree, err = backTrack(gs, moves, tree)
-			if err != nil {
-				return nil, iteration, err
-			} else {
-				printTreeHistograms(tree, 60)
+/*
+playGameSolver returns a Solver with the original settings: branching over every valid candidate on
+the board, checking children once CheckChildrenDepthThreshold moves have been made, with no
+propagation.
+*/
+func playGameSolver(initialGameState *Game, maxIterations int) *Solver {
+	/*
+		CheckChildDepth counts the givens along with the moves, and without propagation every other
+		set cell is a move.
+	*/
+	var givens int = 0
+	if initialGameState != nil {
+		for _, row := range initialGameState.Grid {
+			for _, value := range row {
+				if value != NotSet {
+					givens++
+				}
 			}
-		} else {
-			var candidate *candidate = candidates.back()
-			candidates = candidates.popBack()
-			tree = append(tree, candidates)
-			moves = append(moves, candidate)
-			gs.addCandidate(candidate)
-			playing = !isFinished(gs)
 		}
 	}
-
-	gs.GamePlayStatistics.Iterations = iteration
-
-	var g *Game = NewGame()
-	for row := 0; row < numRows; row++ {
-		for column := 0; column < numColumns; column++ {
-			g.Grid[row][column] = gs.Grid[row][column]
-		}
+	var creator *AsyncCandidateListCreator = &AsyncCandidateListCreator{
+		CheckChildDepth: givens + CheckChildrenDepthThreshold,
 	}
 
-	return g, gs.GamePlayStatistics.Iterations, nil
+	return &Solver{
+		IterationReportInterval: 10000,
+		MaximumIterations: maxIterations,
+		ChildCreator: creator,
+		Engine: BacktrackingEngine{},
+	}
 }
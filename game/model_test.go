@@ -5,6 +5,7 @@ import (
 
 	"bytes"
 	"fmt"
+	"math/rand"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -886,3 +887,14 @@ func Test_Json(t *testing.T) {
 		jsonString := gs.Json()
 		t.Log(jsonString)
 }
+
+func Test_shuffleCandidatesWith(t *testing.T) {
+	var first candidateList = createAllCandidatesList()
+	var second candidateList = createAllCandidatesList()
+	shuffleCandidatesWith(rand.New(rand.NewSource(7)), first)
+	shuffleCandidatesWith(rand.New(rand.NewSource(7)), second)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, createAllCandidatesList(), first)
+	assert.Len(t, first, 9*9*9)
+}
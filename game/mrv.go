@@ -0,0 +1,134 @@
+package game
+
+/*
+TieBreak picks between empty cells that are left with the same number of candidates.
+*/
+type TieBreak int
+
+const (
+	/*
+		FirstCell takes the first of the tied cells, reading the board row by row.
+	*/
+	FirstCell TieBreak = iota
+	/*
+		MostConstrained takes the tied cell with the most empty cells among its peers, the cells
+		sharing its row, column or sub grid, so the choice narrows down as much of the board as it can.
+	*/
+	MostConstrained
+	/*
+		RandomCell takes one of the tied cells at random.
+	*/
+	RandomCell
+)
+
+func (tb TieBreak) String() string {
+	switch tb {
+	case FirstCell:
+		return "first"
+	case MostConstrained:
+		return "most-constrained"
+	case RandomCell:
+		return "random"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+MRVCandidateListCreator branches on a single cell, the empty cell with the minimum remaining values,
+instead of every valid candidate on the board.  Each value of that cell is its own branch, so a
+level of the search tree holds at most nine candidates and every level fills a different cell.  A
+cell without candidates yields an empty list, which makes the search back track straight away.
+*/
+type MRVCandidateListCreator struct {
+	TieBreak TieBreak
+}
+
+/*
+peers lists the 20 cells that share a row, column or sub grid with each cell.
+*/
+var peers [numRows * numColumns][]Cell = func() [numRows * numColumns][]Cell {
+	var ret [numRows * numColumns][]Cell
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var list []Cell = make([]Cell, 0, 20)
+			for r := 0; r < numRows; r++ {
+				for c := 0; c < numColumns; c++ {
+					if r == row && c == column {
+						continue
+					}
+					if r == row || c == column || subGridIndex(r, c) == subGridIndex(row, column) {
+						list = append(list, Cell{Row: r, Column: c})
+					}
+				}
+			}
+			ret[row*numColumns+column] = list
+		}
+	}
+
+	return ret
+}()
+
+func (creator *MRVCandidateListCreator) createCandidates(gs *gameState, allCandidates candidateList) candidateList {
+	var best Cell
+	var bestCount int = numCandidates + 1
+	var bestDegree int = -1
+	var ties int = 0
+
+	for row := 0; row < numRows && bestCount > 0; row++ {
+		for column := 0; column < numColumns; column++ {
+			if gs.Grid[row][column] != NotSet {
+				continue
+			}
+			var count int = gs.cellCandidates(row, column).count()
+			if count > bestCount {
+				continue
+			}
+
+			var cell Cell = Cell{Row: row, Column: column}
+			if count < bestCount {
+				best, bestCount, ties = cell, count, 1
+				if creator.TieBreak == MostConstrained {
+					bestDegree = emptyPeers(gs, cell)
+				}
+				if count == 0 {
+					break
+				}
+				continue
+			}
+
+			switch creator.TieBreak {
+			case MostConstrained:
+				if degree := emptyPeers(gs, cell); degree > bestDegree {
+					best, bestDegree = cell, degree
+				}
+			case RandomCell:
+				ties++
+				if gs.intn(ties) == 0 {
+					best = cell
+				}
+			}
+		}
+	}
+
+	var ret candidateList = make(candidateList, 0, numCandidates)
+	if bestCount > numCandidates {
+		return ret
+	}
+	for _, value := range gs.cellCandidates(best.Row, best.Column).values() {
+		ret = append(ret, &candidate{value: value, row: best.Row, column: best.Column})
+	}
+
+	return ret
+}
+
+func emptyPeers(gs *gameState, cell Cell) int {
+	var ret int = 0
+	for _, peer := range peers[cell.Row*numColumns+cell.Column] {
+		if gs.Grid[peer.Row][peer.Column] == NotSet {
+			ret++
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peers(t *testing.T) {
+	for _, list := range peers {
+		assert.Len(t, list, 20)
+	}
+	assert.Contains(t, peers[0], Cell{Row: 2, Column: 2})
+	assert.Contains(t, peers[0], Cell{Row: 8, Column: 0})
+	assert.NotContains(t, peers[0], Cell{Row: 3, Column: 3})
+	assert.NotContains(t, peers[0], Cell{Row: 0, Column: 0})
+}
+
+func Test_MRVCandidateListCreator_createCandidates(t *testing.T) {
+	puzzle, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	gs, err := createGame(puzzle)
+	assert.Nil(t, err)
+
+	var creator *MRVCandidateListCreator = &MRVCandidateListCreator{}
+	var candidates candidateList = creator.createCandidates(gs, createAllCandidatesList())
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, 1, gs.cellCandidates(candidates[0].row, candidates[0].column).count())
+
+	/*
+		Row 0 and sub grid 0 are left with seven candidates a cell.  Row 0, column 2 comes first but
+		the value in row 3, column 2 leaves it with only 17 empty peers, row 0, column 3 has 18.
+	*/
+	gs, err = createGame(NewGame())
+	assert.Nil(t, err)
+	gs.addCandidate(&candidate{value: 0, row: 0, column: 0})
+	gs.addCandidate(&candidate{value: 1, row: 0, column: 1})
+	gs.addCandidate(&candidate{value: 0, row: 3, column: 2})
+
+	creator = &MRVCandidateListCreator{TieBreak: FirstCell}
+	candidates = creator.createCandidates(gs, nil)
+	assert.Len(t, candidates, 7)
+	assert.Equal(t, 0, candidates[0].row)
+	assert.Equal(t, 2, candidates[0].column)
+	assert.Equal(t, 17, emptyPeers(gs, Cell{Row: 0, Column: 2}))
+
+	creator = &MRVCandidateListCreator{TieBreak: MostConstrained}
+	candidates = creator.createCandidates(gs, nil)
+	assert.Len(t, candidates, 7)
+	assert.Equal(t, 0, candidates[0].row)
+	assert.Equal(t, 3, candidates[0].column)
+
+	creator = &MRVCandidateListCreator{TieBreak: RandomCell}
+	candidates = creator.createCandidates(gs, nil)
+	assert.Len(t, candidates, 7)
+}
+
+func Test_MRVCandidateListCreator_createCandidates_deadEnd(t *testing.T) {
+	puzzle, err := ParseGame("12345678." + "........." + "........." + "........." + "........." +
+		"........." + "........." + "........." + "........9")
+	assert.Nil(t, err)
+	gs, err := createGame(puzzle)
+	assert.Nil(t, err)
+
+	var creator *MRVCandidateListCreator = &MRVCandidateListCreator{}
+	assert.Empty(t, creator.createCandidates(gs, nil))
+}
+
+func TestSolver_Solve_mrv(t *testing.T) {
+	for _, tieBreak := range []TieBreak{FirstCell, MostConstrained, RandomCell} {
+		var solver *Solver = CreateSolver()
+		solver.ChildCreator = &MRVCandidateListCreator{TieBreak: tieBreak}
+
+		puzzle, err := ParseGame(hardPuzzle)
+		assert.Nil(t, err)
+
+		solution, stats, err := solver.Solve(puzzle)
+		assert.Nil(t, err, tieBreak.String())
+		assert.Equal(t, hardPuzzleSolution, FormatLine(solution))
+		assert.Less(t, stats.Iterations, 10000, tieBreak.String())
+	}
+}
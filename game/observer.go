@@ -0,0 +1,131 @@
+package game
+
+/*
+EventKind tells the events of a search apart.
+*/
+type EventKind int
+
+const (
+	/*
+		EventPlacement is a value placed on the board, by branching or, when Forced, by propagation.
+	*/
+	EventPlacement EventKind = iota
+	/*
+		EventBackTrack is the search backing out of a branch that led nowhere.
+	*/
+	EventBackTrack
+	/*
+		EventSolution is a complete board.
+	*/
+	EventSolution
+	/*
+		EventProgress is sent every Solver.IterationReportInterval iterations.
+	*/
+	EventProgress
+)
+
+func (kind EventKind) String() string {
+	switch kind {
+	case EventPlacement:
+		return "placement"
+	case EventBackTrack:
+		return "back track"
+	case EventSolution:
+		return "solution"
+	case EventProgress:
+		return "progress"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+Event is one step of a search.  Depth is the number of placements on top of the puzzle once the
+step is taken.  Fields that do not apply to the Kind are left zero.
+*/
+type Event struct {
+	Kind      EventKind
+	Iteration int
+	Depth     int
+	/*
+		Cell and Digit, 1 through 9, are the placement of an EventPlacement.
+	*/
+	Cell  Cell
+	Digit int
+	/*
+		Forced marks a placement made by propagation rather than by branching.
+	*/
+	Forced bool
+	/*
+		TakenBack is the number of placements an EventBackTrack took back.
+	*/
+	TakenBack int
+	/*
+		Solution is the board of an EventSolution.
+	*/
+	Solution *Game
+	/*
+		BackTracks is the number of placements taken back so far, on an EventProgress.
+	*/
+	BackTracks int
+	/*
+		Remaining holds the branches still to try at each depth, from the first placement down, on
+		an EventProgress.
+	*/
+	Remaining []int
+	/*
+		SetCount is the number of cells set, givens included, on an EventProgress.
+	*/
+	SetCount int
+}
+
+/*
+Observer receives the events of a search as they happen.  Observe is called on the goroutine doing
+the search, so it holds the search up while it runs; with a ParallelSolver it is called from
+several goroutines at once.
+*/
+type Observer interface {
+	Observe(event Event)
+}
+
+/*
+ObserverFunc lets an ordinary function be an Observer.
+*/
+type ObserverFunc func(event Event)
+
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+/*
+observer returns the Observer of a search, nil when nothing is watching.  The solver's Logger,
+when it logs debug messages, watches along with the Observer.
+*/
+func (solver *Solver) observer() Observer {
+	var logObserver Observer = newLogObserver(solver.logger())
+	switch {
+	case solver.Observer == nil:
+		return logObserver
+	case logObserver == nil:
+		return solver.Observer
+	}
+
+	return ObserverFunc(func(event Event) {
+		solver.Observer.Observe(event)
+		logObserver.Observe(event)
+	})
+}
+
+/*
+placementEvent describes c placed on the board, leaving depth placements.
+*/
+func placementEvent(c *candidate, iteration int, depth int, forced bool) Event {
+	return Event{
+		Kind:      EventPlacement,
+		Iteration: iteration,
+		Depth:     depth,
+		Cell:      Cell{Row: c.row, Column: c.column},
+		Digit:     c.value + 1,
+		Forced:    forced,
+	}
+}
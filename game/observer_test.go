@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolver_Observer(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	for _, engine := range []Engine{BacktrackingEngine{}, DLXEngine{}} {
+		var events map[EventKind]int = make(map[EventKind]int)
+		var depth int = 0
+		var solutions []*Game = make([]*Game, 0)
+		var solver *Solver = CreateSolver()
+		solver.Engine = engine
+		solver.IterationReportInterval = 10
+		solver.Observer = ObserverFunc(func(event Event) {
+			events[event.Kind]++
+			switch event.Kind {
+			case EventPlacement:
+				depth++
+				assert.Equal(t, depth, event.Depth)
+				assert.True(t, event.Digit >= 1 && event.Digit <= 9)
+			case EventBackTrack:
+				depth -= event.TakenBack
+				assert.Equal(t, depth, event.Depth)
+			case EventSolution:
+				solutions = append(solutions, event.Solution)
+				assert.Equal(t, depth, event.Depth)
+			case EventProgress:
+				assert.Equal(t, depth, event.Depth)
+				assert.Len(t, event.Remaining, depth)
+			}
+		})
+
+		_, stats, err := solver.Solve(game)
+		assert.Nil(t, err)
+		assert.Equal(t, 81-21, depth)
+		assert.Len(t, solutions, 1)
+		assert.Equal(t, hardPuzzleSolution, FormatLine(solutions[0]))
+		assert.Equal(t, stats.Iterations/10, events[EventProgress])
+		assert.Greater(t, events[EventBackTrack], 0)
+	}
+}
+
+func TestSolver_observer(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	assert.Nil(t, solver.observer())
+
+	var events int = 0
+	solver.Observer = ObserverFunc(func(Event) { events++ })
+	solver.observer().Observe(Event{})
+	assert.Equal(t, 1, events)
+}
@@ -0,0 +1,361 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+/*
+ParallelSolver spreads the search of one puzzle over several goroutines.  The top levels of the
+search tree are expanded into independent subproblems, each a set of placements on top of the
+puzzle, and every subproblem is searched with the Solver's engine.  Each worker keeps a deque of
+subproblems, working from the back of its own and stealing from the front of the others' when it
+runs dry.
+
+The subproblems split the solutions between them without overlap, so solutions can be counted in
+parallel as well as found.  MaximumIterations applies to each subproblem on its own.  Each
+subproblem draws its random choices from a source seeded with the Solver's Seed plus the number of
+the subproblem; Solver.Rand, which is not safe to share, is not used.
+*/
+type ParallelSolver struct {
+	/*
+		Solver configures the search of each subproblem; CreateSolver is used when it is nil.
+	*/
+	Solver *Solver
+	/*
+		Workers is the number of goroutines, GOMAXPROCS when it is not positive.
+	*/
+	Workers int
+	/*
+		Deterministic makes Solve return the same solution on every run: the first one in the order
+		the subproblems were split off, rather than whichever worker finishes first.  The search of
+		each subproblem has to be deterministic too: DLXEngine always is, the back tracking search is
+		when the Solver has a Seed.  Subproblems before the one holding the answer are still searched
+		to the end, so a deterministic solve can take longer.
+	*/
+	Deterministic bool
+}
+
+/*
+parallelSubproblemsPerWorker is how many subproblems the split aims for per worker, enough for
+stealing to even out subproblems of very different sizes.
+*/
+const parallelSubproblemsPerWorker int = 8
+
+/*
+parallelMaximumSplitDepth bounds the levels expanded when a puzzle does not branch much.
+*/
+const parallelMaximumSplitDepth int = 8
+
+type parallelTask struct {
+	index  int
+	moves  candidateList
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+/*
+taskDeque is a worker's queue of subproblems.  They are queued last first, so the owner, taking
+from the back, works through its share in the order they were split off, while thieves take from
+the front.
+*/
+type taskDeque struct {
+	lock  sync.Mutex
+	tasks []*parallelTask
+}
+
+func (deque *taskDeque) pushBack(task *parallelTask) {
+	deque.lock.Lock()
+	defer deque.lock.Unlock()
+	deque.tasks = append(deque.tasks, task)
+}
+
+func (deque *taskDeque) popBack() *parallelTask {
+	deque.lock.Lock()
+	defer deque.lock.Unlock()
+	if len(deque.tasks) == 0 {
+		return nil
+	}
+	var task *parallelTask = deque.tasks[len(deque.tasks)-1]
+	deque.tasks = deque.tasks[:len(deque.tasks)-1]
+
+	return task
+}
+
+func (deque *taskDeque) steal() *parallelTask {
+	deque.lock.Lock()
+	defer deque.lock.Unlock()
+	if len(deque.tasks) == 0 {
+		return nil
+	}
+	var task *parallelTask = deque.tasks[0]
+	deque.tasks = deque.tasks[1:]
+
+	return task
+}
+
+func (ps *ParallelSolver) solver() *Solver {
+	if ps.Solver == nil {
+		return CreateSolver()
+	}
+
+	return ps.Solver
+}
+
+func (ps *ParallelSolver) workers() int {
+	if ps.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return ps.Workers
+}
+
+/*
+Solve solves game in parallel, see Solver.Solve.  The first solution found stops the other workers.
+*/
+func (ps *ParallelSolver) Solve(game *Game) (*Game, *GamePlayStatistics, error) {
+	return ps.SolveContext(context.Background(), game)
+}
+
+/*
+SolveContext is Solve with a context, see Solver.SolveContext.  When the search is cut short the
+fullest board reached by any of the subproblems is returned with the error.
+*/
+func (ps *ParallelSolver) SolveContext(ctx context.Context, game *Game) (*Game, *GamePlayStatistics, error) {
+	if game == nil {
+		return nil, nil, errors.New("game is nil on call to Solve.")
+	}
+
+	var lock sync.Mutex
+	var solution *Game
+	var solutionIndex int = -1
+	var partial *Game
+	var partialSet int = -1
+
+	statistics, err := ps.run(ctx, ps.solver(), game, func(tasks []*parallelTask, task *parallelTask,
+		gs *gameState) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		if solution == nil || (ps.Deterministic && task.index < solutionIndex) {
+			solution = gs.toGame()
+			solutionIndex = task.index
+		}
+		for _, other := range tasks {
+			if !ps.Deterministic || other.index > task.index {
+				other.cancel()
+			}
+		}
+
+		return false
+	}, func(gs *gameState) {
+		lock.Lock()
+		defer lock.Unlock()
+		var board *Game = gs.bestGame()
+		if set := (&gameState{Grid: board.Grid}).setCount(); set > partialSet {
+			partial = board
+			partialSet = set
+		}
+	})
+
+	if solution != nil {
+		err = nil
+	} else if err == nil {
+		err = ErrNoSolution
+	}
+	if statistics != nil {
+		ps.solver().logSolve(ctx, statistics, err)
+	}
+
+	if solution != nil {
+		return solution, statistics, nil
+	}
+	if err != ErrNoSolution {
+		return partial, statistics, err
+	}
+
+	return nil, statistics, err
+}
+
+/*
+CountSolutions counts the solutions of game in parallel, see Solver.CountSolutions.
+*/
+func (ps *ParallelSolver) CountSolutions(game *Game, limit int) (int, error) {
+	if game == nil {
+		return 0, errors.New("game is nil on call to CountSolutions.")
+	}
+
+	var lock sync.Mutex
+	var count int = 0
+
+	var solver *Solver = ps.solver().enumerator()
+	_, err := ps.run(context.Background(), solver, game, func(tasks []*parallelTask, task *parallelTask,
+		gs *gameState) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		if limit > 0 && count >= limit {
+			return false
+		}
+		count++
+		if limit > 0 && count >= limit {
+			for _, other := range tasks {
+				other.cancel()
+			}
+			return false
+		}
+
+		return true
+	}, nil)
+
+	if limit > 0 && count >= limit {
+		return count, nil
+	}
+
+	return count, err
+}
+
+/*
+run splits game into subproblems and searches them on the workers, calling visit with every
+solution found.  visit returns whether the search of its subproblem goes on, and may cancel other
+subproblems; those stop without reporting an error.  cutShort, when set, is given the board of
+a subproblem whose search was cut short by the iteration limit or by ctx.  The error returned is
+the first of those.
+*/
+func (ps *ParallelSolver) run(ctx context.Context, solver *Solver, game *Game,
+	visit func(tasks []*parallelTask, task *parallelTask, gs *gameState) bool,
+	cutShort func(gs *gameState)) (*GamePlayStatistics, error) {
+	root, err := createGame(game)
+	if err != nil {
+		return nil, err
+	}
+
+	var workers int = ps.workers()
+	var tasks []*parallelTask = make([]*parallelTask, 0)
+	for i, moves := range splitSearch(root, workers*parallelSubproblemsPerWorker) {
+		var task *parallelTask = &parallelTask{index: i, moves: moves}
+		task.ctx, task.cancel = context.WithCancel(ctx)
+		tasks = append(tasks, task)
+	}
+	defer func() {
+		for _, task := range tasks {
+			task.cancel()
+		}
+	}()
+
+	var deques []*taskDeque = make([]*taskDeque, workers)
+	for i := range deques {
+		deques[i] = &taskDeque{}
+	}
+	for i := len(tasks) - 1; i >= 0; i-- {
+		deques[i%workers].pushBack(tasks[i])
+	}
+
+	var lock sync.Mutex
+	var firstErr error
+	var seed int64 = pickSeed(solver.Seed)
+	var statistics *GamePlayStatistics = &GamePlayStatistics{Seed: seed}
+	statistics.start()
+	var wait sync.WaitGroup
+
+	var next = func(worker int) *parallelTask {
+		if task := deques[worker].popBack(); task != nil {
+			return task
+		}
+		for i := 1; i < workers; i++ {
+			if task := deques[(worker+i)%workers].steal(); task != nil {
+				return task
+			}
+		}
+
+		return nil
+	}
+
+	wait.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func(worker int) {
+			defer wait.Done()
+			for task := next(worker); task != nil; task = next(worker) {
+				if task.ctx.Err() != nil && ctx.Err() == nil {
+					continue
+				}
+
+				var gs *gameState = root.clone()
+				gs.random = rand.New(rand.NewSource(seed + int64(task.index)))
+				for _, c := range task.moves {
+					gs.addCandidate(c)
+				}
+				var taskStatistics *GamePlayStatistics = &GamePlayStatistics{started: statistics.started}
+				var err error = solver.engine().search(task.ctx, solver, gs, taskStatistics, func(gs *gameState) bool {
+					return visit(tasks, task, gs)
+				})
+
+				lock.Lock()
+				statistics.add(taskStatistics, len(task.moves))
+				/*
+					A subproblem canceled because another found the answer is not an error.
+				*/
+				if err != nil && (err == ErrMaximumIterations || ctx.Err() != nil) {
+					if firstErr == nil {
+						firstErr = err
+					}
+					if cutShort != nil {
+						cutShort(gs)
+					}
+				}
+				lock.Unlock()
+			}
+		}(worker)
+	}
+	wait.Wait()
+	statistics.finish()
+
+	if ctx.Err() != nil && firstErr == nil {
+		firstErr = stopped(ctx, statistics)
+	}
+
+	return statistics, firstErr
+}
+
+/*
+splitSearch expands the top levels of the search of gs breadth first, branching on the cell with
+the fewest candidates after propagating singles, until there are at least target subproblems or
+nothing left to branch on.  Each subproblem is the list of placements leading to it.  Branches
+that propagation shows to be dead ends are dropped, so a puzzle without a solution may give none.
+*/
+func splitSearch(gs *gameState, target int) []candidateList {
+	var creator *MRVCandidateListCreator = &MRVCandidateListCreator{}
+	var subproblems []candidateList = []candidateList{{}}
+
+	for depth := 0; depth < parallelMaximumSplitDepth && len(subproblems) < target; depth++ {
+		var next []candidateList = make([]candidateList, 0, len(subproblems)*numCandidates)
+		var branched bool = false
+		for _, moves := range subproblems {
+			var board *gameState = gs.clone()
+			for _, c := range moves {
+				board.addCandidate(c)
+			}
+			forced, consistent := propagate(board)
+			if !consistent {
+				continue
+			}
+
+			var base candidateList = append(append(make(candidateList, 0, len(moves)+len(forced)+1), moves...), forced...)
+			if isFinished(board) {
+				next = append(next, base)
+				continue
+			}
+			for _, c := range creator.createCandidates(board, nil) {
+				next = append(next, append(append(make(candidateList, 0, len(base)+1), base...), c))
+				branched = true
+			}
+		}
+		subproblems = next
+		if !branched {
+			break
+		}
+	}
+
+	return subproblems
+}
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_taskDeque(t *testing.T) {
+	var deque *taskDeque = &taskDeque{}
+	assert.Nil(t, deque.popBack())
+	assert.Nil(t, deque.steal())
+
+	for i := 0; i < 3; i++ {
+		deque.pushBack(&parallelTask{index: i})
+	}
+	assert.Equal(t, 2, deque.popBack().index)
+	assert.Equal(t, 0, deque.steal().index)
+	assert.Equal(t, 1, deque.popBack().index)
+	assert.Nil(t, deque.steal())
+}
+
+func Test_splitSearch(t *testing.T) {
+	gs, err := createGame(NewGame())
+	assert.Nil(t, err)
+
+	var subproblems []candidateList = splitSearch(gs, 16)
+	assert.GreaterOrEqual(t, len(subproblems), 16)
+	var seen map[string]bool = make(map[string]bool)
+	for _, moves := range subproblems {
+		var board *gameState = gs.clone()
+		for _, c := range moves {
+			assert.True(t, board.canPlace(c))
+			board.addCandidate(c)
+		}
+		seen[FormatLine(board.toGame())] = true
+	}
+	assert.Len(t, seen, len(subproblems))
+
+	/*
+		Singles finish the easy puzzle, leaving a single subproblem holding the whole solution.
+	*/
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	gs, err = createGame(game)
+	assert.Nil(t, err)
+	subproblems = splitSearch(gs, 16)
+	assert.Len(t, subproblems, 1)
+	assert.Len(t, subproblems[0], 81-30)
+
+	game, err = ParseGame("12345678." + strings.Repeat(".", 71) + "9")
+	assert.Nil(t, err)
+	gs, err = createGame(game)
+	assert.Nil(t, err)
+	assert.Empty(t, splitSearch(gs, 16))
+}
+
+func TestParallelSolver_Solve(t *testing.T) {
+	var goroutines int = runtime.NumGoroutine()
+	var solver *ParallelSolver = &ParallelSolver{Workers: 4}
+
+	for _, test := range []struct {
+		puzzle   string
+		solution string
+	}{
+		{easyPuzzle, easyPuzzleSolution},
+		{hardPuzzle, hardPuzzleSolution},
+	} {
+		game, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+
+		solution, stats, err := solver.Solve(game)
+		assert.Nil(t, err)
+		assert.Equal(t, test.solution, FormatLine(solution))
+		assert.True(t, solution.IsGiven(0, 0))
+		/*
+			The subproblem that found the solution filled the board, counting the moves that split it off.
+		*/
+		assert.Equal(t, strings.Count(test.puzzle, "."), stats.MaxDepth)
+	}
+
+	solution, _, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.True(t, isFinished(&gameState{Grid: solution.Grid}))
+	assert.Nil(t, validateGameState(&gameState{Grid: solution.Grid}))
+
+	game, err := ParseGame("12345678." + strings.Repeat(".", 71) + "9")
+	assert.Nil(t, err)
+	_, _, err = solver.Solve(game)
+	assert.ErrorIs(t, err, ErrNoSolution)
+
+	assert.Equal(t, goroutines, runtime.NumGoroutine())
+}
+
+func TestParallelSolver_Solve_deterministic(t *testing.T) {
+	var sequential *Solver = CreateSolver()
+	sequential.Engine = DLXEngine{}
+	var solver *ParallelSolver = &ParallelSolver{Solver: sequential, Workers: 4, Deterministic: true}
+
+	first, _, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		solution, _, err := solver.Solve(NewGame())
+		assert.Nil(t, err)
+		assert.Equal(t, FormatLine(first), FormatLine(solution))
+	}
+}
+
+func TestParallelSolver_SolveContext(t *testing.T) {
+	var solver *ParallelSolver = &ParallelSolver{Workers: 2}
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	partial, _, err := solver.SolveContext(ctx, game)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotNil(t, partial)
+}
+
+func TestParallelSolver_SolveContext_best(t *testing.T) {
+	var solver *ParallelSolver = &ParallelSolver{Workers: 2, Solver: CreateSolver()}
+	solver.Solver.Propagate = false
+	solver.Solver.MaximumIterations = 50
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	partial, _, err := solver.SolveContext(context.Background(), game)
+	assert.ErrorIs(t, err, ErrMaximumIterations)
+	assert.Nil(t, validateGameState(&gameState{Grid: partial.Grid}))
+	assert.Greater(t, (&gameState{Grid: partial.Grid}).setCount(), (&gameState{Grid: game.Grid}).setCount())
+}
+
+func TestParallelSolver_CountSolutions(t *testing.T) {
+	var solver *ParallelSolver = &ParallelSolver{Workers: 4}
+
+	for _, test := range []struct {
+		puzzle string
+		limit  int
+		expect int
+	}{
+		{hardPuzzle, 0, 1},
+		{twoSolutionPuzzle, 0, 2},
+		{twoSolutionPuzzle, 1, 1},
+		{"12345678." + strings.Repeat(".", 71) + "9", 0, 0},
+		{"", 100, 100},
+	} {
+		var game *Game = NewGame()
+		if test.puzzle != "" {
+			var err error
+			game, err = ParseGame(test.puzzle)
+			assert.Nil(t, err)
+		}
+
+		count, err := solver.CountSolutions(game, test.limit)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expect, count, test.puzzle)
+	}
+}
+
+func TestParallelSolver_CountSolutions_matchesSolver(t *testing.T) {
+	/*
+		The easy puzzle without its first two rows has a few thousand solutions.
+	*/
+	game, err := ParseGame(strings.Repeat(".", 18) + easyPuzzle[18:])
+	assert.Nil(t, err)
+
+	expect, err := CreateSolver().CountSolutions(game, 0)
+	assert.Nil(t, err)
+	assert.Greater(t, expect, 1)
+
+	actual, err := (&ParallelSolver{Workers: 4}).CountSolutions(game, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, expect, actual)
+}
+
+func TestParallelSolver_Solve_seeded(t *testing.T) {
+	var sequential *Solver = CreateSolver()
+	sequential.Seed = 7
+	var solver *ParallelSolver = &ParallelSolver{Solver: sequential, Workers: 4, Deterministic: true}
+
+	first, stats, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), stats.Seed)
+	for i := 0; i < 10; i++ {
+		solution, _, err := solver.Solve(NewGame())
+		assert.Nil(t, err)
+		assert.Equal(t, FormatLine(first), FormatLine(solution))
+	}
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+ParseGame reads a puzzle in the common 81 character line format.
+
+Digits 1-9 are givens and '.', '0' or '-' mark an empty cell.  Whitespace and the '|' and '+'
+separators used by many collections are ignored, as are border lines such as ---+---+---, so a
+grid spread over nine lines, boxed or not, parses the same way as a single line.  The user facing
+digits 1-9 are stored as the internal values 0-8.
+*/
+func ParseGame(s string) (*Game, error) {
+	return parseGame(s, 1)
+}
+
+/*
+ReadGame reads everything from r and parses it with ParseGame.
+*/
+func ReadGame(r io.Reader) (*Game, error) {
+	if r == nil {
+		return nil, errors.New("reader is nil on call to ReadGame")
+	}
+
+	var buf strings.Builder
+	_, err := io.Copy(&buf, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGame(buf.String())
+}
+
+/*
+parseGame does the work for ParseGame.  firstLine is the line number that s starts on, which lets
+callers reading a file one line at a time report positions relative to the whole file.
+*/
+func parseGame(s string, firstLine int) (*Game, error) {
+	var game *Game = NewGame()
+	var cells int = 0
+	var line int = firstLine
+
+	for i, text := range strings.Split(s, "\n") {
+		line = firstLine + i
+		if isBorderLine(text) {
+			continue
+		}
+
+		for index, r := range []rune(text) {
+			var column int = index + 1
+			var value int
+			switch {
+			case r >= '1' && r <= '9':
+				value = int(r - '1')
+			case r == '.' || r == '0' || r == '-':
+				value = NotSet
+			case r == '|' || r == '+' || r == ' ' || r == '\t' || r == '\r':
+				continue
+			default:
+				return nil, errors.New(fmt.Sprintf("line %d, column %d: unexpected character %q", line, column, r))
+			}
+
+			if cells >= numRows*numColumns {
+				return nil, errors.New(fmt.Sprintf("line %d, column %d: puzzle has more than %d cells",
+					line, column, numRows*numColumns))
+			}
+
+			game.Grid[cells/numColumns][cells%numColumns] = value
+			cells++
+		}
+	}
+
+	if cells != numRows*numColumns {
+		return nil, errors.New(fmt.Sprintf("line %d: puzzle has %d cells, expected %d",
+			line, cells, numRows*numColumns))
+	}
+
+	return game, nil
+}
+
+/*
+isBorderLine reports whether text is a border between boxes, made only of '-', '+', '|' and
+spaces: one with a '+', such as ---+---+---, or one whose '|' separated runs of dashes are all
+wider than the cells of a box, such as -------|-------|-------.  Any other line of dashes, such as
+---|---|--- or a line of 18, is a run of empty cells.
+*/
+func isBorderLine(text string) bool {
+	var dashes int = 0
+	var plus, bar bool = false, false
+	for _, r := range text {
+		switch r {
+		case '-':
+			dashes++
+		case '+':
+			plus = true
+		case '|':
+			bar = true
+		case ' ', '\t', '\r':
+		default:
+			return false
+		}
+	}
+	if dashes == 0 {
+		return false
+	}
+	if plus {
+		return true
+	}
+	if !bar {
+		return false
+	}
+
+	for _, run := range strings.Split(text, "|") {
+		run = strings.TrimSpace(run)
+		if run != "" && len(run) <= subGridColumns {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const easyPuzzle string = "53..7....6..195....98....6.8...6...34..8.3..17...2...6.6....28....419..5....8..79"
+const easyPuzzleSolution string = "534678912672195348198342567859761423426853791713924856961537284287419635345286179"
+
+func Test_ParseGame(t *testing.T) {
+	var game *Game
+	var err error
+
+	game, err = ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, game.Grid[0][0])
+	assert.Equal(t, 2, game.Grid[0][1])
+	assert.Equal(t, NotSet, game.Grid[0][2])
+	assert.Equal(t, 8, game.Grid[8][8])
+
+	/* zeros, dashes and separators */
+	game, err = ParseGame(strings.Replace(easyPuzzle, ".", "0", 10))
+	assert.Nil(t, err)
+	assert.Equal(t, NotSet, game.Grid[0][2])
+
+	var boxed string = `
+53.|.7.|...
+6..|195|...
+-98|---|-6-
+---+---+---
+8..|.6.|..3
+4..|8.3|..1
+7..|.2.|..6
+---+---+---
+.6.|...|28.
+...|419|..5
+...|.8.|.79`
+	game, err = ParseGame(boxed)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, game.Grid[2][7])
+	assert.Equal(t, NotSet, game.Grid[2][0])
+	assert.Equal(t, 2, game.Grid[3][8])
+
+	/* wider borders, and a row of empty cells written with dashes */
+	game, err = ParseGame(strings.Replace(strings.Replace(boxed, "---+---+---", "------+-------+------", -1),
+		".6.|...|28.", "---|---|---", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, NotSet, game.Grid[6][1])
+	assert.Equal(t, 3, game.Grid[7][3])
+
+	/* an empty grid written entirely with dashes */
+	game, err = ParseGame(strings.Repeat("-", 81))
+	assert.Nil(t, err)
+	assert.Equal(t, NotSet, game.Grid[8][8])
+
+	/* lines of dashes holding two or three rows of empty cells */
+	game, err = ParseGame(strings.Repeat("-", 18) + "\n" + strings.Repeat(".", 63))
+	assert.Nil(t, err)
+	assert.Equal(t, NotSet, game.Grid[1][8])
+	game, err = ParseGame(strings.Repeat("-", 27) + "\n" + easyPuzzle[27:])
+	assert.Nil(t, err)
+	assert.Equal(t, NotSet, game.Grid[2][7])
+	assert.Equal(t, 7, game.Grid[3][0])
+
+	/* borders drawn with bars only, as wide as the boxes they separate */
+	game, err = ParseGame(strings.Replace(boxed, "---+---+---", "-------|-------|-------", -1))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, game.Grid[3][8])
+}
+
+func Test_ParseGame_errors(t *testing.T) {
+	var err error
+
+	_, err = ParseGame(easyPuzzle[:80])
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "80 cells")
+
+	_, err = ParseGame(easyPuzzle + "1")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "column 82")
+
+	_, err = ParseGame("53..7....\n6..x95....98....6.8...6...34..8.3..17...2...6.6....28....419..5....8..79")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 2, column 4")
+}
+
+func Test_ReadGame(t *testing.T) {
+	game, err := ReadGame(strings.NewReader(easyPuzzle + "\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, game.Grid[0][0])
+
+	_, err = ReadGame(nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+A small PDF writer covering what the booklet needs: pages of lines and text in the
+standard Helvetica fonts.  The standard fonts are built into every PDF reader, so nothing has to be
+embedded and no external tools are involved.
+*/
+
+const pdfRegularFont string = "F1"
+const pdfBoldFont string = "F2"
+
+/*
+Widths of the printable ASCII characters, space through tilde, in thousandths of the font size.
+Taken from the Adobe font metrics of Helvetica and Helvetica-Bold.
+*/
+var pdfRegularWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var pdfBoldWidths = [...]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+type pdfDocument struct {
+	width  float64
+	height float64
+	title  string
+	pages  []*pdfPage
+}
+
+/*
+pdfPage collects the content stream of one page.  Coordinates passed to its methods start at the
+top left corner of the page and grow down, the conversion to PDF space happens here.
+*/
+type pdfPage struct {
+	document *pdfDocument
+	content  bytes.Buffer
+}
+
+func newPDFDocument(width, height float64, title string) *pdfDocument {
+	var ret *pdfDocument = &pdfDocument{
+		width:  width,
+		height: height,
+		title:  title,
+		pages:  make([]*pdfPage, 0),
+	}
+
+	return ret
+}
+
+func (doc *pdfDocument) addPage() *pdfPage {
+	var page *pdfPage = &pdfPage{
+		document: doc,
+	}
+	doc.pages = append(doc.pages, page)
+
+	return page
+}
+
+func (page *pdfPage) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&page.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n",
+		width, x1, page.document.height-y1, x2, page.document.height-y2)
+}
+
+/*
+text draws s with its baseline at y, starting at x.  gray runs from 0 for black to 1 for white.
+*/
+func (page *pdfPage) text(x, y float64, font string, size float64, gray float64, s string) {
+	fmt.Fprintf(&page.content, "BT %.2f g /%s %.2f Tf %.2f %.2f Td (%s) Tj ET 0 g\n",
+		gray, font, size, x, page.document.height-y, pdfEscape(s))
+}
+
+func (page *pdfPage) centeredText(x, y float64, font string, size float64, gray float64, s string) {
+	page.text(x-pdfTextWidth(font, size, s)/2, y, font, size, gray, s)
+}
+
+func pdfTextWidth(font string, size float64, s string) float64 {
+	var widths []int = pdfRegularWidths[:]
+	if font == pdfBoldFont {
+		widths = pdfBoldWidths[:]
+	}
+
+	var total int = 0
+	for _, r := range s {
+		if r < ' ' || r > '~' {
+			r = '?'
+		}
+		total += widths[r-' ']
+	}
+
+	return float64(total) * size / 1000
+}
+
+/*
+pdfEscape keeps s to printable ASCII, which the WinAnsi encoding of the standard fonts maps
+directly, and escapes the characters that are special inside a PDF string.
+*/
+func pdfEscape(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r < ' ' || r > '~':
+			buf.WriteByte('?')
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
+/*
+write lays the document out as objects 1 catalog, 2 page tree, 3 and 4 fonts, 5 info, then a page
+object followed by its content stream for every page, and finishes with the cross reference table.
+*/
+func (doc *pdfDocument) write(w io.Writer) error {
+	var buf *pdfWriter = &pdfWriter{writer: bufio.NewWriter(w)}
+	var firstPage int = 6
+	var objects int = firstPage + 2*len(doc.pages)
+	var offsets []int = make([]int, objects)
+
+	var kids strings.Builder
+	for i := range doc.pages {
+		fmt.Fprintf(&kids, "%d 0 R ", firstPage+2*i)
+	}
+
+	buf.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	offsets[1] = buf.offset
+	buf.printf("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	offsets[2] = buf.offset
+	buf.printf("2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.TrimSpace(kids.String()), len(doc.pages))
+	offsets[3] = buf.offset
+	buf.printf("3 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+	offsets[4] = buf.offset
+	buf.printf("4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+	offsets[5] = buf.offset
+	buf.printf("5 0 obj\n<< /Title (%s) /Producer (github.com/jkeene-NAN/sudoku) >>\nendobj\n", pdfEscape(doc.title))
+
+	for i, page := range doc.pages {
+		var pageObject int = firstPage + 2*i
+		offsets[pageObject] = buf.offset
+		buf.printf("%d 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pageObject, doc.width, doc.height, pdfRegularFont, pdfBoldFont, pageObject+1)
+		offsets[pageObject+1] = buf.offset
+		buf.printf("%d 0 obj\n<< /Length %d >>\nstream\n", pageObject+1, page.content.Len())
+		buf.write(page.content.Bytes())
+		buf.printf("\nendstream\nendobj\n")
+	}
+
+	var xref int = buf.offset
+	buf.printf("xref\n0 %d\n0000000000 65535 f \n", objects)
+	for i := 1; i < objects; i++ {
+		buf.printf("%010d 00000 n \n", offsets[i])
+	}
+	buf.printf("trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", objects, xref)
+
+	if buf.err != nil {
+		return buf.err
+	}
+	return buf.writer.Flush()
+}
+
+/*
+pdfWriter tracks the byte offset of everything written so the cross reference table can point at
+each object, and holds on to the first error so the layout code can write without checking.
+*/
+type pdfWriter struct {
+	writer *bufio.Writer
+	offset int
+	err    error
+}
+
+func (pw *pdfWriter) printf(format string, args ...interface{}) {
+	pw.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+func (pw *pdfWriter) write(data []byte) {
+	if pw.err != nil {
+		return
+	}
+	var n int
+	n, pw.err = pw.writer.Write(data)
+	pw.offset += n
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"runtime"
+	"sync"
+)
+
+/*
+candidatePool checks candidate lists on a fixed set of worker goroutines.  Each worker owns a
+scratch board it copies the board under test into, so no board is cloned per candidate; a check
+still allocates its jobs, the keep flags and the returned list.  A list is split into a few chunks
+per worker rather than a goroutine per candidate.  Each search starts a pool of its own and closes
+it before returning.
+*/
+type candidatePool struct {
+	jobs      chan *candidateJob
+	workers   sync.WaitGroup
+	closeOnce sync.Once
+	size      int
+}
+
+/*
+candidateJob checks allCandidates[start:end] against gs, setting keep for each candidate that
+passes.  Jobs of one call share gs, which no worker writes to.
+*/
+type candidateJob struct {
+	gs            *gameState
+	allCandidates candidateList
+	checkChild    bool
+	start         int
+	end           int
+	keep          []bool
+	done          *sync.WaitGroup
+}
+
+/*
+candidateChunksPerWorker splits a list finely enough that a worker that finishes early can pick up
+part of the remaining work.
+*/
+const candidateChunksPerWorker int = 4
+
+/*
+newCandidatePool starts size workers, GOMAXPROCS when size is not positive.
+*/
+func newCandidatePool(size int) *candidatePool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+
+	var pool *candidatePool = &candidatePool{
+		jobs: make(chan *candidateJob, size*candidateChunksPerWorker),
+		size: size,
+	}
+
+	pool.workers.Add(size)
+	for i := 0; i < size; i++ {
+		go pool.work()
+	}
+
+	return pool
+}
+
+func (pool *candidatePool) work() {
+	defer pool.workers.Done()
+
+	var scratch *gameState = newScratchGameState()
+	for job := range pool.jobs {
+		job.gs.copyInto(scratch)
+		for i := job.start; i < job.end; i++ {
+			var c *candidate = job.allCandidates[i]
+			if !scratch.canPlace(c) {
+				continue
+			}
+			if !job.checkChild {
+				job.keep[i] = true
+				continue
+			}
+			scratch.addCandidate(c)
+			job.keep[i] = countValidCandidates(scratch, job.allCandidates) >= scratch.movesRemaining()
+			scratch.removeCandidate(c)
+		}
+		job.done.Done()
+	}
+}
+
+/*
+validCandidates returns the candidates of allCandidates that pass the same checks as
+createValidCandidateList, in the same order, whatever order the workers finish in.
+*/
+func (pool *candidatePool) validCandidates(gs *gameState, allCandidates candidateList, checkChild bool) candidateList {
+	var keep []bool = make([]bool, len(allCandidates))
+	var done sync.WaitGroup
+	var chunks int = pool.size * candidateChunksPerWorker
+	var chunkSize int = (len(allCandidates) + chunks - 1) / chunks
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+
+	for start := 0; start < len(allCandidates); start += chunkSize {
+		done.Add(1)
+		pool.jobs <- &candidateJob{
+			gs:            gs,
+			allCandidates: allCandidates,
+			checkChild:    checkChild,
+			start:         start,
+			end:           min(start+chunkSize, len(allCandidates)),
+			keep:          keep,
+			done:          &done,
+		}
+	}
+	done.Wait()
+
+	var ret candidateList = make(candidateList, 0, len(allCandidates))
+	for i, c := range allCandidates {
+		if keep[i] {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
+/*
+close stops the workers once they have finished the jobs already handed to them.
+*/
+func (pool *candidatePool) close() {
+	pool.closeOnce.Do(func() {
+		close(pool.jobs)
+	})
+	pool.workers.Wait()
+}
+
+func newScratchGameState() *gameState {
+	var ret *gameState = &gameState{
+		Grid: make([][]int, numRows),
+	}
+	for row := 0; row < numRows; row++ {
+		ret.Grid[row] = make([]int, numColumns)
+	}
+
+	return ret
+}
+
+/*
+copyInto makes dst a copy of gs without allocating.  dst needs a full size Grid, as made by
+newScratchGameState.
+*/
+func (gs *gameState) copyInto(dst *gameState) {
+	for row := 0; row < numRows; row++ {
+		copy(dst.Grid[row], gs.Grid[row])
+	}
+	dst.initialGameState = gs.initialGameState
+	dst.rowMasks = gs.rowMasks
+	dst.columnMasks = gs.columnMasks
+	dst.subGridMasks = gs.subGridMasks
+	dst.rowCounts = gs.rowCounts
+	dst.columnCounts = gs.columnCounts
+	dst.subGridCounts = gs.subGridCounts
+}
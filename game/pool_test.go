@@ -0,0 +1,117 @@
+package game
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_candidatePool_validCandidates(t *testing.T) {
+	var pool *candidatePool = newCandidatePool(3)
+	defer pool.close()
+
+	var allCandidates candidateList = createAllCandidatesList()
+	for _, puzzle := range []string{"", easyPuzzle, hardPuzzle} {
+		var game *Game = NewGame()
+		if puzzle != "" {
+			var err error
+			game, err = ParseGame(puzzle)
+			assert.Nil(t, err)
+		}
+		gs, err := createGame(game)
+		assert.Nil(t, err)
+
+		for _, checkChild := range []bool{false, true} {
+			var expect candidateList = createValidCandidateList(gs, allCandidates, checkChild)
+			var actual candidateList = pool.validCandidates(gs, allCandidates, checkChild)
+			assert.Equal(t, expect, actual)
+		}
+	}
+
+	/*
+		Fewer candidates than chunks still get checked, one to a chunk.
+	*/
+	gs, err := createGame(NewGame())
+	assert.Nil(t, err)
+	assert.Len(t, pool.validCandidates(gs, allCandidates[:2], false), 2)
+	assert.Empty(t, pool.validCandidates(gs, candidateList{}, false))
+}
+
+/*
+settles reports whether the number of goroutines comes down to at most goroutines within a few
+seconds.  A worker is still counted for a moment after it tells its WaitGroup it is done.
+*/
+func settles(goroutines int) bool {
+	var deadline time.Time = time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return true
+}
+
+func Test_candidatePool_close(t *testing.T) {
+	var pool *candidatePool = newCandidatePool(4)
+	var stopped chan struct{} = make(chan struct{})
+	go func() {
+		pool.workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		assert.Fail(t, "workers stopped before close")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pool.close()
+	pool.close()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "workers still running after close")
+	}
+}
+
+func Test_AsyncCandidateListCreator_workers(t *testing.T) {
+	var goroutines int = runtime.NumGoroutine()
+	var creator *AsyncCandidateListCreator = &AsyncCandidateListCreator{CheckChildDepth: 50, Workers: 2}
+
+	/*
+		Outside of a search each call starts a pool and stops it again.
+	*/
+	gs, err := createGame(NewGame())
+	assert.Nil(t, err)
+	var allCandidates candidateList = createAllCandidatesList()
+	assert.Len(t, creator.createCandidates(gs, allCandidates), len(allCandidates))
+	assert.True(t, settles(goroutines))
+
+	/*
+		A search stops its pool before it returns.
+	*/
+	var solver *Solver = CreateSolver()
+	solver.ChildCreator = creator
+	_, _, err = solver.Solve(mustParse(t, easyPuzzle))
+	assert.Nil(t, err)
+	assert.True(t, settles(goroutines))
+}
+
+func Test_gameState_copyInto(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	gs, err := createGame(game)
+	assert.Nil(t, err)
+
+	var scratch *gameState = newScratchGameState()
+	gs.copyInto(scratch)
+	assert.Equal(t, gs.Grid, scratch.Grid)
+	assert.Equal(t, gs.rowMasks, scratch.rowMasks)
+	assert.Equal(t, gs.subGridCounts, scratch.subGridCounts)
+
+	scratch.addCandidate(&candidate{value: 3, row: 0, column: 2})
+	assert.Equal(t, NotSet, gs.Grid[0][2])
+}
@@ -0,0 +1,97 @@
+package game
+
+const numUnits int = numRows + numColumns + numSubSquares
+
+/*
+units lists the cells of every row, column and sub grid, in that order: units 0-8 are the rows,
+9-17 the columns and 18-26 the sub grids.
+*/
+var units [numUnits][numCandidates]Cell = func() [numUnits][numCandidates]Cell {
+	var ret [numUnits][numCandidates]Cell
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var subGrid int = subGridIndex(row, column)
+			var position int = (row%subGridRows)*subGridColumns + column%subGridColumns
+			ret[row][column] = Cell{Row: row, Column: column}
+			ret[numRows+column][row] = Cell{Row: row, Column: column}
+			ret[numRows+numColumns+subGrid][position] = Cell{Row: row, Column: column}
+		}
+	}
+
+	return ret
+}()
+
+/*
+unitMask returns the values already placed in a unit.
+*/
+func (gs *gameState) unitMask(unit int) digitSet {
+	switch {
+	case unit < numRows:
+		return gs.rowMasks[unit]
+	case unit < numRows+numColumns:
+		return gs.columnMasks[unit-numRows]
+	default:
+		return gs.subGridMasks[unit-numRows-numColumns]
+	}
+}
+
+/*
+propagate places forced values until there are none left: naked singles, cells with one candidate,
+and hidden singles, values with one possible cell in a row, column or sub grid.  It returns the
+placements it made in order, and false if it found an empty cell without candidates or a value
+with no cell left in some unit.  The placements stay on gs in either case, so the caller can
+record them as moves and take them back together.
+*/
+func propagate(gs *gameState) (candidateList, bool) {
+	var forced candidateList = make(candidateList, 0)
+	var progress bool = true
+
+	for progress {
+		progress = false
+
+		for row := 0; row < numRows; row++ {
+			for column := 0; column < numColumns; column++ {
+				if gs.Grid[row][column] != NotSet {
+					continue
+				}
+				var candidates digitSet = gs.cellCandidates(row, column)
+				switch candidates.count() {
+				case 0:
+					return forced, false
+				case 1:
+					var c *candidate = &candidate{value: candidates.first(), row: row, column: column}
+					gs.addCandidate(c)
+					forced = append(forced, c)
+					progress = true
+				}
+			}
+		}
+
+		for unit := 0; unit < numUnits; unit++ {
+			for value := 0; value < numCandidates; value++ {
+				if gs.unitMask(unit).has(value) {
+					continue
+				}
+				var places int = 0
+				var place Cell
+				for _, cell := range units[unit] {
+					if gs.cellCandidates(cell.Row, cell.Column).has(value) {
+						places++
+						place = cell
+					}
+				}
+				switch places {
+				case 0:
+					return forced, false
+				case 1:
+					var c *candidate = &candidate{value: value, row: place.Row, column: place.Column}
+					gs.addCandidate(c)
+					forced = append(forced, c)
+					progress = true
+				}
+			}
+		}
+	}
+
+	return forced, true
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_units(t *testing.T) {
+	assert.Equal(t, Cell{Row: 4, Column: 0}, units[4][0])
+	assert.Equal(t, Cell{Row: 2, Column: 7}, units[numRows+7][2])
+	assert.Equal(t, Cell{Row: 3, Column: 3}, units[numRows+numColumns+4][0])
+	assert.Equal(t, Cell{Row: 5, Column: 5}, units[numRows+numColumns+4][8])
+}
+
+func Test_propagate(t *testing.T) {
+	puzzle, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+	gs, err := createGame(puzzle)
+	assert.Nil(t, err)
+
+	forced, consistent := propagate(gs)
+	assert.True(t, consistent)
+	assert.Equal(t, 81-30, len(forced))
+	assert.Equal(t, easyPuzzleSolution, FormatLine(gs.toGame()))
+}
+
+func Test_propagate_contradiction(t *testing.T) {
+	/*
+		The last cell of the first row has to be a 9, which its column already holds.
+	*/
+	puzzle, err := ParseGame("12345678." + "........." + "........." + "........." + "........." +
+		"........." + "........." + "........." + "........9")
+	assert.Nil(t, err)
+	gs, err := createGame(puzzle)
+	assert.Nil(t, err)
+
+	forced, consistent := propagate(gs)
+	assert.False(t, consistent)
+	assert.Empty(t, forced)
+}
+
+func TestSolver_Solve_propagate(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	assert.True(t, solver.Propagate)
+
+	puzzle, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	solution, stats, err := solver.Solve(puzzle)
+	assert.Nil(t, err)
+	assert.Equal(t, easyPuzzleSolution, FormatLine(solution))
+	assert.Equal(t, 1, stats.Iterations)
+	assert.Equal(t, 0, stats.BackTracks)
+	assert.Equal(t, 81-30, stats.Propagations)
+}
+
+func TestSolver_Solve_propagateNoSolution(t *testing.T) {
+	var solver *Solver = CreateSolver()
+
+	puzzle, err := ParseGame("12345678." + "........." + "........." + "........." + "........." +
+		"........." + "........." + "........." + "........9")
+	assert.Nil(t, err)
+
+	_, _, err = solver.Solve(puzzle)
+	assert.ErrorIs(t, err, ErrNoSolution)
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+A Puzzle is one entry of a puzzle collection along with the metadata that was stored next to it.
+*/
+type Puzzle struct {
+	Game   *Game
+	Name   string
+	Rating string
+	/*
+		Fields holds any ';' separated fields after the rating.
+	*/
+	Fields []string
+	/*
+		Line is the line of the input the puzzle was read from, starting at 1.
+	*/
+	Line int
+}
+
+/*
+PuzzleReader streams puzzles from a collection stored one puzzle per line.
+
+Blank lines and lines starting with '#' are skipped, as is anything after a '#' on a puzzle line.
+A puzzle may be followed by ";name;rating" fields as used by the common benchmark sets.
+*/
+type PuzzleReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewPuzzleReader(r io.Reader) *PuzzleReader {
+	var ret *PuzzleReader = &PuzzleReader{
+		scanner: bufio.NewScanner(r),
+	}
+
+	return ret
+}
+
+/*
+Next returns the next puzzle in the collection, or io.EOF when there are no more.
+A malformed line returns an error together with a Puzzle that has no Game but does carry the
+line number and metadata, and the reader moves on so Next can be called again.
+*/
+func (pr *PuzzleReader) Next() (*Puzzle, error) {
+	for pr.scanner.Scan() {
+		pr.line++
+		var text string = pr.scanner.Text()
+		if index := strings.IndexByte(text, '#'); index >= 0 {
+			text = text[:index]
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		var fields []string = strings.Split(text, ";")
+		var puzzle *Puzzle = &Puzzle{
+			Line: pr.line,
+		}
+		if len(fields) > 1 {
+			puzzle.Name = strings.TrimSpace(fields[1])
+		}
+		if len(fields) > 2 {
+			puzzle.Rating = strings.TrimSpace(fields[2])
+		}
+		for _, field := range fields[min(len(fields), 3):] {
+			puzzle.Fields = append(puzzle.Fields, strings.TrimSpace(field))
+		}
+
+		var err error
+		puzzle.Game, err = parseGame(fields[0], pr.line)
+		if err != nil {
+			return puzzle, err
+		}
+
+		return puzzle, nil
+	}
+
+	if err := pr.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+/*
+SolveEach reads puzzles from r one at a time and hands each one to handle along with the result of
+solving it.  Puzzles that fail to parse are passed to handle with a nil solution and the parse error.
+Reading stops at the end of the input or when handle returns an error, which is then returned.
+*/
+func (solver *Solver) SolveEach(r io.Reader,
+	handle func(puzzle *Puzzle, solution *Game, statistics *GamePlayStatistics, err error) error) error {
+	if r == nil {
+		return errors.New("reader is nil on call to SolveEach")
+	}
+
+	var reader *PuzzleReader = NewPuzzleReader(r)
+	for {
+		puzzle, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if puzzle == nil {
+			return errors.New(fmt.Sprintf("reading puzzles: %v", err))
+		}
+
+		var solution *Game
+		var statistics *GamePlayStatistics
+		if err == nil {
+			solution, statistics, err = solver.Solve(puzzle.Game)
+		}
+
+		err = handle(puzzle, solution, statistics, err)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PuzzleReader_Next(t *testing.T) {
+	var input string = "# a collection\n" +
+		"\n" +
+		easyPuzzle + ";easy one;1.2\n" +
+		"  # indented comment\n" +
+		easyPuzzleSolution + " # solved already\n" +
+		"12345\n" +
+		easyPuzzle + ";named;3.4;extra;more\n"
+
+	var reader *PuzzleReader = NewPuzzleReader(strings.NewReader(input))
+
+	puzzle, err := reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, puzzle.Line)
+	assert.Equal(t, "easy one", puzzle.Name)
+	assert.Equal(t, "1.2", puzzle.Rating)
+	assert.Equal(t, 4, puzzle.Game.Grid[0][0])
+
+	puzzle, err = reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, puzzle.Line)
+	assert.Equal(t, "", puzzle.Name)
+
+	puzzle, err = reader.Next()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 6")
+	assert.Equal(t, 6, puzzle.Line)
+	assert.Nil(t, puzzle.Game)
+
+	puzzle, err = reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"extra", "more"}, puzzle.Fields)
+
+	puzzle, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+	assert.Nil(t, puzzle)
+}
+
+func TestSolver_SolveEach(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	var input string = easyPuzzleSolution + ";first\nnot a puzzle\n" + easyPuzzleSolution + ";second\n"
+	var names []string
+	var failures int
+
+	err := solver.SolveEach(strings.NewReader(input),
+		func(puzzle *Puzzle, solution *Game, statistics *GamePlayStatistics, err error) error {
+			if err != nil {
+				failures++
+				assert.Nil(t, solution)
+				return nil
+			}
+			names = append(names, puzzle.Name)
+			assert.Equal(t, puzzle.Game.Grid, solution.Grid)
+			return nil
+		})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, failures)
+	assert.Equal(t, []string{"first", "second"}, names)
+
+	err = solver.SolveEach(strings.NewReader(input),
+		func(puzzle *Puzzle, solution *Game, statistics *GamePlayStatistics, err error) error {
+			return io.ErrUnexpectedEOF
+		})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
@@ -1,75 +1,73 @@
 package game
 
 import (
-	"log"
-	"os"
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"math/rand"
 	"errors"
-	"sync"
 )
 
-var logger *log.Logger
-
-func init() {
-	logger = log.New(os.Stdout, "Solver", log.LstdFlags)
-}
-
 
 type CandidateListCreator interface {
 	createCandidates(gs *gameState, allCandidates candidateList) candidateList
 }
 
+/*
+AsyncCandidateListCreator branches over every valid candidate on the board.  The candidates are
+checked on a pool of Workers goroutines, GOMAXPROCS when Workers is not positive.  A search starts
+the pool when it begins and stops it before it returns; a call outside of a search starts and stops
+a pool of its own.
+*/
 type AsyncCandidateListCreator struct {
+	/*
+		CheckChildDepth is the number of set cells, givens included, from which a candidate is only
+		kept when the board it leads to still has candidates for every empty cell.
+	*/
 	CheckChildDepth int
+	Workers int
 }
 
 func (creator *AsyncCandidateListCreator) createCandidates(gs *gameState,
 	allCandidate candidateList) candidateList {
+	var pool *candidatePool = newCandidatePool(creator.Workers)
+	defer pool.close()
 
+	return creator.check(pool, gs, allCandidate)
+}
 
-	var wait sync.WaitGroup
-	wait.Add(len(allCandidate))
-	var candidateChannel chan *candidate = make(chan *candidate, len(allCandidate))
-	var validCandidates candidateList = make(candidateList, 0)
+func (creator *AsyncCandidateListCreator) check(pool *candidatePool, gs *gameState,
+	allCandidate candidateList) candidateList {
 	var checkChild bool = gs.setCount() >= creator.CheckChildDepth
 
-	var doCheck = func(clone *gameState, c *candidate) {
-		defer wait.Done()
-		var toAdd *candidate
-		clone.addCandidate(c)
-		var err error = validateGameState(clone)
-		if err == nil {
-			if checkChild {
-				var childValidCandidates candidateList = createValidCandidateList(clone, allCandidate, false)
-				if len(childValidCandidates) >= clone.movesRemaining() {
-					toAdd = c
-				} else {
-					toAdd = nil
-				}
-			} else {
-				toAdd = c
-			}
-		} else {
-			toAdd = nil
-		}
-
-		candidateChannel <- toAdd
-	}
+	return pool.validCandidates(gs, allCandidate, checkChild)
+}
 
-	for _, candidate := range allCandidate {
-		go doCheck(gs.clone(), candidate)
-	}
+/*
+pooledCandidateListCreator is an AsyncCandidateListCreator bound to the pool of one search.
+*/
+type pooledCandidateListCreator struct {
+	creator *AsyncCandidateListCreator
+	pool *candidatePool
+}
 
-	wait.Wait()
+func (pooled pooledCandidateListCreator) createCandidates(gs *gameState,
+	allCandidate candidateList) candidateList {
+	return pooled.creator.check(pooled.pool, gs, allCandidate)
+}
 
-	for i := 0; i < len(allCandidate); i++ {
-		var c *candidate
-		c = <-candidateChannel
-		if c != nil {
-			validCandidates = append(validCandidates, c)
-		}
+/*
+startCandidateListCreator readies creator for one search.  The returned stop function ends
+whatever it started, and must be called before the search returns.
+*/
+func startCandidateListCreator(creator CandidateListCreator) (CandidateListCreator, func()) {
+	if async, ok := creator.(*AsyncCandidateListCreator); ok {
+		var pool *candidatePool = newCandidatePool(async.Workers)
+		return pooledCandidateListCreator{creator: async, pool: pool}, pool.close
 	}
 
-	return validCandidates
+	return creator, func() {}
 }
 
 /*
@@ -108,30 +106,125 @@ func (b Backtracker) backTrack(gs *gameState, moves candidateList, tree searchTr
 	return doBackTrack(0, gs, moves, tree)
 }
 
+/*
+ErrMaximumIterations is returned along with the best grid reached when a search runs out of
+iterations before finishing.
+*/
+var ErrMaximumIterations = errors.New("maximum iterations reached")
+
+/*
+ErrNoSolution is returned when a search has tried everything and found no solution.
+*/
+var ErrNoSolution = errors.New("puzzle has no solution")
+
+/*
+Engine is the search a Solver runs.  BacktrackingEngine is used when a Solver has no Engine.
+*/
+type Engine interface {
+	/*
+		search fills in gs, counting its work in statistics, and calls visit with gs every time the
+		board is complete.  The search goes on to the next solution while visit returns true; when
+		visit returns false search returns straight away, leaving the solution on gs.  search returns
+		nil once it stops or runs out of branches, and ErrMaximumIterations if the solver's iteration
+		limit is reached first, leaving gs at the point the search stopped.  It stops the same way
+		with the error of stopped when ctx is done.  Every board the search gets deeper with is kept
+		with gs.keepBest.
+	*/
+	search(ctx context.Context, solver *Solver, gs *gameState, statistics *GamePlayStatistics, visit func(gs *gameState) bool) error
+}
+
+/*
+BacktrackingEngine is the original search: place a candidate from the solver's ChildCreator, and
+back track when a board has no candidates left.
+*/
+type BacktrackingEngine struct {
+}
+
 /**
 Solver with configuration to turn off logging and adjust log output levels.
  */
 type Solver struct {
+	/*
+		Logger receives the log of each solve: a summary at info level and the progress of the search
+		at debug level, every IterationReportInterval iterations.  With no Logger the solver logs
+		nothing, unless Log is set, which logs to slog.Default().
+	*/
+	Logger *slog.Logger
 	Log bool
 	IterationReportInterval int
 	MaximumIterations int
 	ChildCreator CandidateListCreator
+	Engine Engine
+	/*
+		Observer is told about every step of a search, see Event.
+	*/
+	Observer Observer
+	/*
+		Propagate places the forced values, naked and hidden singles, before every branch of the
+		back tracking search and backs out of a branch as soon as it runs into a contradiction.
+		The DLX engine always works this way and ignores the setting.
+	*/
+	Propagate bool
+	/*
+		SnapShotInterval records a SnapShot in the statistics every so many iterations, none when 0.
+	*/
+	SnapShotInterval int
+	/*
+		Seed makes the random choices of a search, the order candidates are tried in and RandomCell
+		tie breaks, the same on every run.  With no Seed each search picks its own, which is reported
+		in GamePlayStatistics.Seed; setting Seed to it replays that search exactly.
+	*/
+	Seed int64
+	/*
+		Rand, when set, is used for the random choices in place of a source made from Seed, and
+		GamePlayStatistics.Seed is left 0 as the search cannot be replayed from a seed.  It is not safe
+		for concurrent use, so a ParallelSolver ignores it.
+	*/
+	Rand *rand.Rand
+}
+
+/*
+random returns the source of random choices for a search and the seed to report for it, 0 when
+the choices come from Rand, whose seed is not known.
+*/
+func (solver *Solver) random() (*rand.Rand, int64) {
+	if solver.Rand != nil {
+		return solver.Rand, 0
+	}
+
+	var seed int64 = pickSeed(solver.Seed)
+	return rand.New(rand.NewSource(seed)), seed
+}
+
+/*
+pickSeed returns seed, or a random seed in its place when it is 0.
+*/
+func pickSeed(seed int64) int64 {
+	for seed == 0 {
+		seed = rand.Int63()
+	}
+
+	return seed
 }
 
 /**
 Creates a Solver with some default configurations.
 The configuration options turn off logging and set both the IterationReportingInterval
 and the MaximumIterations to entirely arbitrary values.
-CheckChildren is also set to True.  Setting this to false will result in a considerably higher run time.
+The search branches on the empty cell with the fewest candidates and propagates singles before each
+branch.  An AsyncCandidateListCreator as ChildCreator branches over every valid candidate on the
+board instead, which is considerably slower.
  */
 func CreateSolver() *Solver {
 	var ret *Solver = &Solver {
 		Log: false,
 		IterationReportInterval: 100,
 		MaximumIterations: 1000000,
-		ChildCreator: &AsyncCandidateListCreator{
-			CheckChildDepth: 50,
+		ChildCreator: &MRVCandidateListCreator{
+			TieBreak: FirstCell,
 		},
+		Engine: BacktrackingEngine{},
+		Propagate: true,
 	}
 
 	return ret
@@ -140,10 +233,23 @@ func CreateSolver() *Solver {
 
 /*
 Solves a sudoku puzzle.
-Returns a game state representing the best the solver could do (should solve anything that is solvable,
-some game play statistics that are not well implemented, and an error that is nil if everything went well.
+Returns the solution, the GamePlayStatistics of the search (iterations, back tracks, propagations,
+nodes expanded, candidates evaluated, the deepest level reached with the branching at each level,
+wall time, snap shots and the seed) and an error that is nil if everything went well.  A puzzle with
+no solution returns a nil game with ErrNoSolution.  When the iteration limit is reached the fullest
+grid the search reached is returned with ErrMaximumIterations.
  */
 func (solver *Solver) Solve(game *Game) (*Game, *GamePlayStatistics, error) {
+	return solver.SolveContext(context.Background(), game)
+}
+
+/*
+SolveContext is Solve with a context.  The search checks ctx before every iteration; once ctx is
+canceled or its deadline passes it stops and returns the fullest board it reached with an error that
+wraps ctx.Err(), so errors.Is(err, context.DeadlineExceeded) tells a timeout from a cancellation.
+No goroutines started for the search outlive the call.
+*/
+func (solver *Solver) SolveContext(ctx context.Context, game *Game) (*Game, *GamePlayStatistics, error) {
 	if game == nil {
 		return nil, nil, errors.New("game is nil on call to Solve.")
 	}
@@ -158,43 +264,260 @@ func (solver *Solver) Solve(game *Game) (*Game, *GamePlayStatistics, error) {
 		return nil, nil, err
 	}
 
+	var found bool = false
+	var gamePlayStatistics *GamePlayStatistics = &GamePlayStatistics{}
+	gs.random, gamePlayStatistics.Seed = solver.random()
+	gamePlayStatistics.start()
+	err = solver.engine().search(ctx, solver, gs, gamePlayStatistics, func(*gameState) bool {
+		found = true
+		return false
+	})
+	gamePlayStatistics.finish()
+	if !found && err == nil {
+		err = ErrNoSolution
+	}
+	solver.logSolve(ctx, gamePlayStatistics, err)
+	if err == ErrNoSolution {
+		return nil, gamePlayStatistics, err
+	}
+	if err != nil {
+		return gs.bestGame(), gamePlayStatistics, err
+	}
+
+	return gs.toGame(), gamePlayStatistics, nil
+}
+
+/*
+CountSolutions searches past the first solution and returns how many solutions game has, stopping
+once limit solutions are found.  A limit of 0 counts every solution, which is only practical for
+grids that are nearly full.  When the iteration limit is reached the count so far is returned with
+ErrMaximumIterations.
+*/
+func (solver *Solver) CountSolutions(game *Game, limit int) (int, error) {
+	if game == nil {
+		return 0, errors.New("game is nil on call to CountSolutions.")
+	}
+
+	var count int = 0
+	var err error = solver.EachSolution(game, func(*Game) bool {
+		count++
+		return limit <= 0 || count < limit
+	})
+
+	return count, err
+}
+
+/*
+EachSolution calls handle with every solution of game in turn, until handle returns false or the
+search runs out of branches.  The returned error is nil in both cases; it is ErrMaximumIterations
+when the solver's iteration limit cuts the search short.
+
+Branching over every candidate on the board reaches the same solution along many paths, so with an
+AsyncCandidateListCreator the search branches on one cell at a time instead.
+*/
+func (solver *Solver) EachSolution(game *Game, handle func(solution *Game) bool) error {
+	if game == nil {
+		return errors.New("game is nil on call to EachSolution.")
+	}
+
+	gs, err := createGame(game)
+	if err != nil {
+		return err
+	}
+
+	var enumerator *Solver = solver.enumerator()
+	gs.random, _ = solver.random()
+	return enumerator.engine().search(context.Background(), enumerator, gs, &GamePlayStatistics{}, func(gs *gameState) bool {
+		return handle(gs.toGame())
+	})
+}
+
+/*
+Solutions returns an iterator over the solutions of game, see EachSolution.  Breaking out of the
+loop stops the search.  An error ends the sequence as a nil game with the error:
+
+	for solution, err := range solver.Solutions(game) {
+		if err != nil {
+			...
+		}
+	}
+*/
+func (solver *Solver) Solutions(game *Game) iter.Seq2[*Game, error] {
+	return func(yield func(*Game, error) bool) {
+		var stopped bool = false
+		var err error = solver.EachSolution(game, func(solution *Game) bool {
+			stopped = !yield(solution, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+/*
+HasUniqueSolution reports whether game has exactly one solution.  A puzzle without a solution
+is not unique either.
+*/
+func (solver *Solver) HasUniqueSolution(game *Game) (bool, error) {
+	count, err := solver.CountSolutions(game, 2)
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+/*
+stopped is the error a search returns when ctx is done.
+*/
+func stopped(ctx context.Context, statistics *GamePlayStatistics) error {
+	return fmt.Errorf("search stopped after %d iterations: %w", statistics.Iterations, ctx.Err())
+}
+
+/*
+enumerator returns solver, or a copy branching on one cell at a time if solver branches over the
+whole board, for searches that have to see each solution once.
+*/
+func (solver *Solver) enumerator() *Solver {
+	if _, ok := solver.ChildCreator.(*MRVCandidateListCreator); ok {
+		return solver
+	}
+
+	var ret Solver = *solver
+	ret.ChildCreator = &MRVCandidateListCreator{}
+	return &ret
+}
+
+func (solver *Solver) engine() Engine {
+	if solver.Engine == nil {
+		return BacktrackingEngine{}
+	}
+
+	return solver.Engine
+}
+
+func (engine BacktrackingEngine) search(ctx context.Context, solver *Solver, gs *gameState, gamePlayStatistics *GamePlayStatistics,
+	visit func(gs *gameState) bool) error {
+	var err error
 	var allCandidates candidateList = createAllCandidatesList()
 	var tree searchTree = make(searchTree, 0, 81)
 	var moves candidateList = make(candidateList, 0, 81)
-	var playing bool = !isFinished(gs)
-	var iteration int = 0
 	var snapShotModulo int = solver.IterationReportInterval
 	var maxIterations int = solver.MaximumIterations
-	var logging bool = solver.Log
-	var gamePlayStatistics *GamePlayStatistics = &GamePlayStatistics{}
-	var childCreator CandidateListCreator = solver.ChildCreator
+	var observer Observer = solver.observer()
+	childCreator, stop := startCandidateListCreator(solver.ChildCreator)
+	defer stop()
+
+	/*
+		back takes back the latest branch, reporting false when there is none left to try.
+	*/
+	var back = func() bool {
+		if len(tree) == 0 {
+			return false
+		}
+		var depth int = len(moves)
+		gs, moves, tree, err = backTrack(gs, moves, tree)
+		if err != nil {
+			gamePlayStatistics.BackTracks += depth
+			if observer != nil {
+				observer.Observe(Event{
+					Kind:      EventBackTrack,
+					Iteration: gamePlayStatistics.Iterations,
+					TakenBack: depth,
+				})
+			}
+			return false
+		}
+		/*
+			backTrack takes back every placement down to a level with something left to try, then
+			places the next candidate of that level.
+		*/
+		gamePlayStatistics.BackTracks += depth - len(moves) + 1
+		gamePlayStatistics.CandidatesEvaluated++
+		if observer != nil {
+			observer.Observe(Event{
+				Kind:      EventBackTrack,
+				Iteration: gamePlayStatistics.Iterations,
+				Depth:     len(moves) - 1,
+				TakenBack: depth - len(moves) + 1,
+			})
+			observer.Observe(placementEvent(moves.back(), gamePlayStatistics.Iterations, len(moves), false))
+		}
 
-	if logging {
-		logger.Printf("solver.Solve initiating")
+		return true
 	}
 
-	for playing && (gamePlayStatistics.Iterations < maxIterations) {
+	for {
+		if isFinished(gs) {
+			if observer != nil {
+				observer.Observe(Event{
+					Kind:      EventSolution,
+					Iteration: gamePlayStatistics.Iterations,
+					Depth:     len(moves),
+					Solution:  gs.toGame(),
+				})
+			}
+			if !visit(gs) || !back() {
+				return nil
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return stopped(ctx, gamePlayStatistics)
+		}
+		if gamePlayStatistics.Iterations >= maxIterations {
+			return ErrMaximumIterations
+		}
 		gamePlayStatistics.Iterations++
-		if (iteration % snapShotModulo) == 0 {
-			if logging {
-				log.Printf("iteration: %d", iteration)
-				log.Printf("game state set count: %d, len(moves): %d, len(tree): %d, len(tree.back()): %d",
-					gs.setCount(), len(moves), len(tree), len(tree.back()))
-				log.Printf("gs.setCount(): %d", gs.setCount())
+		gamePlayStatistics.snapShot(solver.SnapShotInterval, len(moves), func() []int {
+			return createSearchSnapShot(gs, moves, tree).candidateCountHistogram
+		})
+		if observer != nil && snapShotModulo > 0 && (gamePlayStatistics.Iterations%snapShotModulo) == 0 {
+			observer.Observe(Event{
+				Kind:       EventProgress,
+				Iteration:  gamePlayStatistics.Iterations,
+				Depth:      len(moves),
+				BackTracks: gamePlayStatistics.BackTracks,
+				Remaining:  createSearchSnapShot(gs, moves, tree).candidateCountHistogram,
+				SetCount:   gs.setCount(),
+			})
+		}
+
+		if solver.Propagate {
+			/*
+				Forced values are recorded as moves with nothing left to try at their level, so a
+				back track takes the whole batch back along with the branch that led to it.
+			*/
+			forced, consistent := propagate(gs)
+			gamePlayStatistics.Propagations += len(forced)
+			for _, c := range forced {
+				moves = append(moves, c)
+				tree = append(tree, candidateList{})
+				if observer != nil {
+					observer.Observe(placementEvent(c, gamePlayStatistics.Iterations, len(moves), true))
+				}
+			}
+			gamePlayStatistics.reach(len(moves))
+			if !consistent {
+				if !back() {
+					return nil
+				}
+				continue
+			}
+			gs.keepBest(len(moves))
+			if isFinished(gs) {
+				continue
 			}
 		}
 
 		var candidates candidateList = childCreator.createCandidates(gs, allCandidates)
-		shuffleCandidates(candidates)
+		shuffleCandidatesWith(gs.random, candidates)
+		gamePlayStatistics.expand(len(moves), len(candidates))
 		if len(candidates) == 0 {
-			gs, moves, tree, err = backTrack(gs, moves, tree)
-			if err != nil {
-				return nil,  gamePlayStatistics, err
-			} else {
-				if logging {
-					printTreeHistograms(tree, 60)
-				}
-
+			if !back() {
+				return nil
 			}
 		} else {
 			var candidate *candidate = candidates.back()
@@ -202,23 +525,11 @@ func (solver *Solver) Solve(game *Game) (*Game, *GamePlayStatistics, error) {
 			tree = append(tree, candidates)
 			moves = append(moves, candidate)
 			gs.addCandidate(candidate)
-			playing = !isFinished(gs)
-			if logging {
-				printTreeHistograms(tree, 60)
+			gamePlayStatistics.place(len(moves))
+			gs.keepBest(len(moves))
+			if observer != nil {
+				observer.Observe(placementEvent(candidate, gamePlayStatistics.Iterations, len(moves), false))
 			}
 		}
 	}
-
-	var g *Game = NewGame()
-	for row := 0; row < numRows; row++ {
-		for column := 0; column < numColumns; column++ {
-			g.Grid[row][column] = gs.Grid[row][column]
-		}
-	}
-
-	return g, gamePlayStatistics, nil
 }
-
-
-
-
@@ -1,7 +1,12 @@
 package game
 
 import (
+	"context"
+	"math/rand"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 	"github.com/stretchr/testify/assert"
 	"errors"
 )
@@ -64,4 +69,242 @@ func Test_AsyncCandidateListCreator_createCandidateList(t *testing.T) {
 	var allCandidates candidateList = createAllCandidatesList()
 	var candidates candidateList = candidateListCreator.createCandidates(gs, allCandidates)
 	assert.NotEmpty(t, candidates)
-}
\ No newline at end of file
+}
+
+/*
+twoSolutionPuzzle is the easy puzzle's solution with a rectangle of 6s and 7s emptied out of rows
+0 and 3, which can be filled in either way round.
+*/
+const twoSolutionPuzzle string = "534..8912672195348198342567859..1423426853791713924856961537284287419635345286179"
+
+func TestSolver_CountSolutions(t *testing.T) {
+	for _, engine := range []Engine{BacktrackingEngine{}, DLXEngine{}} {
+		var solver *Solver = CreateSolver()
+		solver.Engine = engine
+
+		for _, test := range []struct {
+			puzzle string
+			limit  int
+			expect int
+		}{
+			{easyPuzzleSolution, 0, 1},
+			{easyPuzzle, 0, 1},
+			{hardPuzzle, 0, 1},
+			{twoSolutionPuzzle, 0, 2},
+			{twoSolutionPuzzle, 1, 1},
+			{"12345678." + strings.Repeat(".", 71) + "9", 0, 0},
+			{"", 5, 5},
+		} {
+			var game *Game = NewGame()
+			if test.puzzle != "" {
+				var err error
+				game, err = ParseGame(test.puzzle)
+				assert.Nil(t, err)
+			}
+
+			count, err := solver.CountSolutions(game, test.limit)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expect, count, test.puzzle)
+		}
+	}
+}
+
+func TestSolver_CountSolutions_async(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.ChildCreator = &AsyncCandidateListCreator{CheckChildDepth: 50}
+
+	game, err := ParseGame(twoSolutionPuzzle)
+	assert.Nil(t, err)
+
+	count, err := solver.CountSolutions(game, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestSolver_CountSolutions_maximumIterations(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.MaximumIterations = 100
+
+	count, err := solver.CountSolutions(NewGame(), 0)
+	assert.ErrorIs(t, err, ErrMaximumIterations)
+	assert.Greater(t, count, 0)
+}
+
+func TestSolver_HasUniqueSolution(t *testing.T) {
+	var solver *Solver = CreateSolver()
+
+	for _, test := range []struct {
+		puzzle string
+		expect bool
+	}{
+		{easyPuzzle, true},
+		{twoSolutionPuzzle, false},
+		{"12345678." + strings.Repeat(".", 71) + "9", false},
+	} {
+		game, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+
+		unique, err := solver.HasUniqueSolution(game)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expect, unique, test.puzzle)
+	}
+}
+
+func TestSolver_EachSolution(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	game, err := ParseGame(twoSolutionPuzzle)
+	assert.Nil(t, err)
+
+	var solutions []string = make([]string, 0)
+	err = solver.EachSolution(game, func(solution *Game) bool {
+		assert.Nil(t, validateGameState(&gameState{Grid: solution.Grid}))
+		assert.True(t, solution.IsGiven(0, 0))
+		assert.False(t, solution.IsGiven(0, 3))
+		solutions = append(solutions, FormatLine(solution))
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Len(t, solutions, 2)
+	assert.Contains(t, solutions, easyPuzzleSolution)
+	assert.Contains(t, solutions, "534768912672195348198342567859671423426853791713924856961537284287419635345286179")
+
+	err = solver.EachSolution(nil, func(*Game) bool { return true })
+	assert.NotNil(t, err)
+}
+
+func TestSolver_Solutions(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.Engine = DLXEngine{}
+
+	var count int = 0
+	for solution, err := range solver.Solutions(NewGame()) {
+		assert.Nil(t, err)
+		assert.True(t, isFinished(&gameState{Grid: solution.Grid}))
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+
+	solver.MaximumIterations = 200
+	var last error
+	count = 0
+	for solution, err := range solver.Solutions(NewGame()) {
+		if err != nil {
+			assert.Nil(t, solution)
+			last = err
+			continue
+		}
+		count++
+	}
+	assert.ErrorIs(t, last, ErrMaximumIterations)
+	assert.Greater(t, count, 0)
+}
+
+func TestSolver_SolveContext(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	for _, engine := range []Engine{BacktrackingEngine{}, DLXEngine{}} {
+		var solver *Solver = CreateSolver()
+		solver.Engine = engine
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		partial, stats, err := solver.SolveContext(ctx, game)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotNil(t, partial)
+		assert.Equal(t, 0, stats.Iterations)
+		assert.Equal(t, 7, partial.Grid[0][0])
+
+		solution, _, err := solver.SolveContext(context.Background(), game)
+		assert.Nil(t, err)
+		assert.Equal(t, hardPuzzleSolution, FormatLine(solution))
+	}
+}
+
+func TestSolver_SolveContext_deadline(t *testing.T) {
+	var goroutines int = runtime.NumGoroutine()
+
+	/*
+		Branching over the whole board takes far longer than the deadline on the hard puzzle.
+	*/
+	var solver *Solver = CreateSolver()
+	solver.ChildCreator = &AsyncCandidateListCreator{CheckChildDepth: 50}
+	solver.Propagate = false
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var start time.Time = time.Now()
+	partial, stats, err := solver.SolveContext(ctx, game)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, errors.Is(err, context.Canceled))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NotNil(t, partial)
+	assert.Greater(t, stats.Iterations, 0)
+	assert.Nil(t, validateGameState(&gameState{Grid: partial.Grid}))
+	assert.Equal(t, goroutines, runtime.NumGoroutine())
+}
+
+func TestSolver_SolveContext_best(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+	var givens int = (&gameState{Grid: game.Grid}).setCount()
+
+	/*
+		Cut short, the search has usually backed out of its deepest branch; the board returned is
+		the one from that branch all the same.
+	*/
+	for _, engine := range []Engine{BacktrackingEngine{}, DLXEngine{}} {
+		var solver *Solver = CreateSolver()
+		solver.Engine = engine
+		solver.Propagate = false
+		solver.MaximumIterations = 500
+
+		partial, stats, err := solver.SolveContext(context.Background(), game)
+		assert.ErrorIs(t, err, ErrMaximumIterations)
+		assert.Nil(t, validateGameState(&gameState{Grid: partial.Grid}))
+		assert.Equal(t, givens+stats.MaxDepth, (&gameState{Grid: partial.Grid}).setCount())
+	}
+}
+
+func TestSolver_Seed(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.ChildCreator = &MRVCandidateListCreator{TieBreak: RandomCell}
+	solver.Seed = 42
+
+	first, stats, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), stats.Seed)
+	second, _, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, FormatLine(first), FormatLine(second))
+
+	/*
+		An unseeded search reports the seed it picked, which replays it.
+	*/
+	solver.Seed = 0
+	first, stats, err = solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.NotEqual(t, int64(0), stats.Seed)
+	solver.Seed = stats.Seed
+	second, _, err = solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, FormatLine(first), FormatLine(second))
+
+	/*
+		With Rand the seed did not drive the search, so none is reported even when one is set.
+	*/
+	solver.Seed = 42
+	solver.Rand = rand.New(rand.NewSource(42))
+	third, stats, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), stats.Seed)
+	solver.Rand = rand.New(rand.NewSource(42))
+	fourth, _, err := solver.Solve(NewGame())
+	assert.Nil(t, err)
+	assert.Equal(t, FormatLine(third), FormatLine(fourth))
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"time"
+)
+
+/*
+DepthStatistics counts the branching at one depth of a search.
+*/
+type DepthStatistics struct {
+	Nodes    int `json:"nodes"`
+	Branches int `json:"branches"`
+}
+
+/*
+SnapShot is the state of a search at one iteration.
+*/
+type SnapShot struct {
+	Iteration  int           `json:"iteration"`
+	Elapsed    time.Duration `json:"elapsed"`
+	Depth      int           `json:"depth"`
+	BackTracks int           `json:"backTracks"`
+	/*
+		Remaining holds the number of branches still to try at each depth, from the first
+		placement down.
+	*/
+	Remaining []int `json:"remaining"`
+}
+
+/*
+start marks the beginning of the search for WallTime and the snap shots.
+*/
+func (stats *GamePlayStatistics) start() {
+	stats.started = time.Now()
+}
+
+func (stats *GamePlayStatistics) finish() {
+	stats.WallTime = time.Since(stats.started)
+}
+
+/*
+expand records a board at depth with branches children.
+*/
+func (stats *GamePlayStatistics) expand(depth, branches int) {
+	stats.NodesExpanded++
+	for len(stats.Depths) <= depth {
+		stats.Depths = append(stats.Depths, DepthStatistics{})
+	}
+	stats.Depths[depth].Nodes++
+	stats.Depths[depth].Branches += branches
+}
+
+/*
+place records a branch taken, leaving depth placements on the board.
+*/
+func (stats *GamePlayStatistics) place(depth int) {
+	stats.CandidatesEvaluated++
+	stats.reach(depth)
+}
+
+func (stats *GamePlayStatistics) reach(depth int) {
+	if depth > stats.MaxDepth {
+		stats.MaxDepth = depth
+	}
+}
+
+/*
+snapShot records a snap shot of the search when interval divides the iteration count.
+remaining is only called when one is due.
+*/
+func (stats *GamePlayStatistics) snapShot(interval int, depth int, remaining func() []int) {
+	if interval <= 0 || stats.Iterations%interval != 0 {
+		return
+	}
+
+	stats.SnapShots = append(stats.SnapShots, SnapShot{
+		Iteration:  stats.Iterations,
+		Elapsed:    time.Since(stats.started),
+		Depth:      depth,
+		BackTracks: stats.BackTracks,
+		Remaining:  remaining(),
+	})
+}
+
+/*
+add folds the statistics of a part of a search, as run by ParallelSolver, into stats.  The part
+starts on a board depth moves into the search, so its depths are moved down by depth to count from
+the board of the whole search.  The snap shots of the parts are kept one after the other.
+*/
+func (stats *GamePlayStatistics) add(other *GamePlayStatistics, depth int) {
+	stats.BackTracks += other.BackTracks
+	stats.Iterations += other.Iterations
+	stats.Propagations += other.Propagations
+	stats.NodesExpanded += other.NodesExpanded
+	stats.CandidatesEvaluated += other.CandidatesEvaluated
+	stats.reach(other.MaxDepth + depth)
+	for partDepth, counts := range other.Depths {
+		for len(stats.Depths) <= partDepth+depth {
+			stats.Depths = append(stats.Depths, DepthStatistics{})
+		}
+		stats.Depths[partDepth+depth].Nodes += counts.Nodes
+		stats.Depths[partDepth+depth].Branches += counts.Branches
+	}
+	for _, snapShot := range other.SnapShots {
+		snapShot.Depth += depth
+		stats.SnapShots = append(stats.SnapShots, snapShot)
+	}
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GamePlayStatistics(t *testing.T) {
+	var stats *GamePlayStatistics = &GamePlayStatistics{}
+	stats.start()
+	stats.expand(0, 3)
+	stats.place(1)
+	stats.expand(2, 2)
+	stats.expand(2, 1)
+	stats.place(3)
+	stats.reach(2)
+
+	assert.Equal(t, 3, stats.NodesExpanded)
+	assert.Equal(t, 2, stats.CandidatesEvaluated)
+	assert.Equal(t, 3, stats.MaxDepth)
+	assert.Equal(t, []DepthStatistics{{Nodes: 1, Branches: 3}, {}, {Nodes: 2, Branches: 3}}, stats.Depths)
+
+	stats.Iterations = 4
+	stats.snapShot(3, 2, func() []int { return []int{1, 0} })
+	assert.Empty(t, stats.SnapShots)
+	stats.snapShot(0, 2, func() []int { return []int{1, 0} })
+	assert.Empty(t, stats.SnapShots)
+	stats.snapShot(2, 2, func() []int { return []int{1, 0} })
+	assert.Len(t, stats.SnapShots, 1)
+	assert.Equal(t, 4, stats.SnapShots[0].Iteration)
+	assert.Equal(t, []int{1, 0}, stats.SnapShots[0].Remaining)
+
+	var total *GamePlayStatistics = &GamePlayStatistics{MaxDepth: 1, Depths: []DepthStatistics{{Nodes: 1, Branches: 1}}}
+	total.add(stats, 0)
+	assert.Equal(t, 3, total.MaxDepth)
+	assert.Equal(t, 4, total.Iterations)
+	assert.Equal(t, []DepthStatistics{{Nodes: 2, Branches: 4}, {}, {Nodes: 2, Branches: 3}}, total.Depths)
+	assert.Len(t, total.SnapShots, 1)
+
+	/*
+		A part that starts two moves in counts its depths from there.
+	*/
+	total.add(stats, 2)
+	assert.Equal(t, 5, total.MaxDepth)
+	assert.Equal(t, []DepthStatistics{{Nodes: 2, Branches: 4}, {}, {Nodes: 3, Branches: 6}, {}, {Nodes: 2, Branches: 3}}, total.Depths)
+	assert.Equal(t, 4, total.SnapShots[1].Depth)
+}
+
+func TestSolver_Solve_statistics(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	for _, engine := range []Engine{BacktrackingEngine{}, DLXEngine{}} {
+		var solver *Solver = CreateSolver()
+		solver.Engine = engine
+		solver.SnapShotInterval = 10
+
+		_, stats, err := solver.Solve(game)
+		assert.Nil(t, err)
+		assert.Greater(t, stats.BackTracks, 0)
+		assert.Greater(t, stats.WallTime.Nanoseconds(), int64(0))
+		assert.Greater(t, stats.MaxDepth, 0)
+		assert.LessOrEqual(t, stats.MaxDepth, 81-21)
+		assert.Greater(t, stats.CandidatesEvaluated, 0)
+
+		var nodes int = 0
+		for _, depth := range stats.Depths {
+			nodes += depth.Nodes
+			assert.GreaterOrEqual(t, depth.Branches, 0)
+		}
+		assert.Equal(t, stats.NodesExpanded, nodes)
+
+		assert.Len(t, stats.SnapShots, stats.Iterations/10)
+		for i, snapShot := range stats.SnapShots {
+			assert.Equal(t, 10*(i+1), snapShot.Iteration)
+			assert.Len(t, snapShot.Remaining, snapShot.Depth)
+			assert.LessOrEqual(t, snapShot.Depth, stats.MaxDepth)
+		}
+	}
+}
+
+func TestSolver_Solve_statisticsDLX(t *testing.T) {
+	/*
+		DLX tries each row once and takes back every row but the ones in the solution.
+	*/
+	var solver *Solver = CreateSolver()
+	solver.Engine = DLXEngine{}
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	_, stats, err := solver.Solve(game)
+	assert.Nil(t, err)
+	assert.Equal(t, stats.Iterations, stats.CandidatesEvaluated)
+	assert.Equal(t, stats.Iterations-(81-21), stats.BackTracks)
+	assert.Equal(t, 81-21, stats.MaxDepth)
+}
+
+func TestSolver_Solve_statisticsJSON(t *testing.T) {
+	var solver *Solver = CreateSolver()
+	solver.SnapShotInterval = 1
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	_, stats, err := solver.Solve(game)
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(stats)
+	assert.Nil(t, err)
+	var decoded GamePlayStatistics
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, stats.Iterations, decoded.Iterations)
+	assert.Equal(t, stats.WallTime, decoded.WallTime)
+	assert.Equal(t, stats.Depths, decoded.Depths)
+	assert.Equal(t, len(stats.SnapShots), len(decoded.SnapShots))
+	assert.Contains(t, string(data), `"nodesExpanded"`)
+}
+
+func Test_PlayGame(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	partial, iterations, err := PlayGame(game, 20)
+	assert.Nil(t, err)
+	assert.NotNil(t, partial)
+	assert.Equal(t, 20, iterations)
+	assert.Nil(t, validateGameState(&gameState{Grid: partial.Grid}))
+
+	_, _, err = PlayGame(nil, 20)
+	assert.NotNil(t, err)
+}
+
+func Test_PlayGameStatistics(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	partial, stats, err := PlayGameStatistics(game, 20)
+	assert.ErrorIs(t, err, ErrMaximumIterations)
+	assert.NotNil(t, partial)
+	assert.Equal(t, 20, stats.Iterations)
+	assert.Greater(t, stats.MaxDepth, 0)
+	assert.Greater(t, stats.NodesExpanded, 0)
+	assert.Nil(t, validateGameState(&gameState{Grid: partial.Grid}))
+}
+
+func Test_playGameSolver(t *testing.T) {
+	game, err := ParseGame(hardPuzzle)
+	assert.Nil(t, err)
+
+	/*
+		Like the original search, children are first checked after CheckChildrenDepthThreshold moves,
+		not once that many cells are set counting the 21 givens.
+	*/
+	var solver *Solver = playGameSolver(game, 20)
+	assert.Equal(t, 21+CheckChildrenDepthThreshold, solver.ChildCreator.(*AsyncCandidateListCreator).CheckChildDepth)
+	assert.False(t, solver.Propagate)
+	assert.Equal(t, 20, solver.MaximumIterations)
+	assert.Equal(t, CheckChildrenDepthThreshold, playGameSolver(NewGame(), 20).ChildCreator.(*AsyncCandidateListCreator).CheckChildDepth)
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const DefaultSVGCellSize int = 48
+
+/*
+SVGOptions controls how WriteSVG draws a game.  The zero value draws the digits only.
+*/
+type SVGOptions struct {
+	/*
+		CellSize is the width and height of a cell in pixels.  DefaultSVGCellSize is used when it is 0.
+	*/
+	CellSize int
+	/*
+		PencilMarks draws the candidates of every empty cell.
+	*/
+	PencilMarks bool
+	/*
+		Candidates overrides the pencil marks with the candidates of an in-progress solve, indexed by
+		row then column and holding internal values 0-8.  When nil the candidates are worked out from
+		the grid.
+	*/
+	Candidates [][][]int
+	/*
+		Highlight lists cells drawn with a highlighted background.
+	*/
+	Highlight []Cell
+}
+
+/*
+WriteSVG draws game as an SVG image.  Givens are drawn in bold black and cells filled by a player or
+the solver in blue.  Every element carries a class (given, filled, candidate, highlight) so a style
+sheet can restyle the image.  Like WriteText, WriteSVG returns an error rather than panicking on a
+malformed game or options.
+*/
+func WriteSVG(w io.Writer, game *Game, options *SVGOptions) error {
+	if game == nil {
+		return errors.New("game is nil on call to WriteSVG")
+	}
+	if options == nil {
+		options = &SVGOptions{}
+	}
+
+	if err := checkDrawable(game, options.Candidates); err != nil {
+		return err
+	}
+
+	var cellSize int = options.CellSize
+	if cellSize <= 0 {
+		cellSize = DefaultSVGCellSize
+	}
+	var size int = cellSize * numColumns
+	var margin int = cellSize / 8
+	var buf *bufio.Writer = bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%d %d %d %d">`+"\n",
+		size+2*margin, size+2*margin, -margin, -margin, size+2*margin, size+2*margin)
+	fmt.Fprintf(buf, `<rect class="board" x="0" y="0" width="%d" height="%d" fill="#ffffff"/>`+"\n", size, size)
+
+	for _, cell := range options.Highlight {
+		if cell.Row < 0 || cell.Row >= numRows || cell.Column < 0 || cell.Column >= numColumns {
+			return errors.New(fmt.Sprintf("highlighted cell out of range: %v", cell))
+		}
+		fmt.Fprintf(buf, `<rect class="highlight" x="%d" y="%d" width="%d" height="%d" fill="#fff3a8"/>`+"\n",
+			cell.Column*cellSize, cell.Row*cellSize, cellSize, cellSize)
+	}
+
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var value int = game.Grid[row][column]
+			var x int = column * cellSize
+			var y int = row * cellSize
+
+			if value != NotSet {
+				var class, weight, color string = "filled", "normal", "#1f5fbf"
+				if game.IsGiven(row, column) {
+					class, weight, color = "given", "bold", "#000000"
+				}
+				fmt.Fprintf(buf, `<text class="%s" x="%d" y="%d" font-family="sans-serif" font-size="%d" `+
+					`font-weight="%s" fill="%s" text-anchor="middle" dominant-baseline="central">%d</text>`+"\n",
+					class, x+cellSize/2, y+cellSize/2, cellSize*3/5, weight, color, value+1)
+				continue
+			}
+
+			if !options.PencilMarks {
+				continue
+			}
+
+			var candidates []int
+			if options.Candidates != nil {
+				candidates = options.Candidates[row][column]
+			} else {
+				candidates = game.Candidates(row, column)
+			}
+			for _, candidate := range candidates {
+				var markX int = x + (candidate%subGridColumns)*cellSize/subGridColumns + cellSize/(2*subGridColumns)
+				var markY int = y + (candidate/subGridRows)*cellSize/subGridRows + cellSize/(2*subGridRows)
+				fmt.Fprintf(buf, `<text class="candidate" x="%d" y="%d" font-family="sans-serif" font-size="%d" `+
+					`fill="#808080" text-anchor="middle" dominant-baseline="central">%d</text>`+"\n",
+					markX, markY, cellSize/4, candidate+1)
+			}
+		}
+	}
+
+	for n := 0; n <= numRows; n++ {
+		var width float64 = 1
+		if n%subGridRows == 0 {
+			width = 3
+		}
+		fmt.Fprintf(buf, `<line x1="0" y1="%d" x2="%d" y2="%d" stroke="#000000" stroke-width="%g" stroke-linecap="square"/>`+"\n",
+			n*cellSize, size, n*cellSize, width)
+		fmt.Fprintf(buf, `<line x1="%d" y1="0" x2="%d" y2="%d" stroke="#000000" stroke-width="%g" stroke-linecap="square"/>`+"\n",
+			n*cellSize, n*cellSize, size, width)
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf.Flush()
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteSVG(t *testing.T) {
+	puzzle, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	err = WriteSVG(&buf, puzzle, nil)
+	assert.Nil(t, err)
+	assert.True(t, wellFormed(buf.String()))
+	assert.Equal(t, 30, strings.Count(buf.String(), `class="given"`))
+	assert.Equal(t, 0, strings.Count(buf.String(), `class="candidate"`))
+	assert.Equal(t, 20, strings.Count(buf.String(), "<line "))
+
+	gs, err := createGame(puzzle)
+	assert.Nil(t, err)
+	gs.addCandidate(&candidate{row: 0, column: 2, value: 3})
+
+	buf.Reset()
+	err = WriteSVG(&buf, gs.toGame(), &SVGOptions{
+		CellSize:    30,
+		PencilMarks: true,
+		Highlight:   []Cell{{Row: 0, Column: 2}, {Row: 8, Column: 8}},
+	})
+	assert.Nil(t, err)
+	assert.True(t, wellFormed(buf.String()))
+	assert.Equal(t, 1, strings.Count(buf.String(), `class="filled"`))
+	assert.Equal(t, 2, strings.Count(buf.String(), `class="highlight"`))
+	assert.Contains(t, buf.String(), `width="270" height="270"`)
+
+	/* (0, 3) can only be 2 or 6 once (0, 2) holds 4 */
+	assert.Equal(t, []int{1, 5}, gs.toGame().Candidates(0, 3))
+	assert.True(t, strings.Count(buf.String(), `class="candidate"`) > 0)
+}
+
+func Test_WriteSVG_candidates(t *testing.T) {
+	var game *Game = NewGame()
+	var candidates [][][]int = make([][][]int, numRows)
+	for row := 0; row < numRows; row++ {
+		candidates[row] = make([][]int, numColumns)
+	}
+	candidates[4][4] = []int{0, 8}
+
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, game, &SVGOptions{PencilMarks: true, Candidates: candidates})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(buf.String(), `class="candidate"`))
+
+	err = WriteSVG(&buf, game, &SVGOptions{Candidates: candidates[:3]})
+	assert.NotNil(t, err)
+
+	err = WriteSVG(&buf, game, &SVGOptions{Highlight: []Cell{{Row: 9}}})
+	assert.NotNil(t, err)
+
+	err = WriteSVG(&buf, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func Test_WriteSVG_malformed(t *testing.T) {
+	var badValue *Game = NewGame()
+	badValue.Grid[3][3] = 9
+	var badGiven *Game = NewGame()
+	badGiven.Grid[0][0] = 0
+	badGiven.Given = [][]bool{{true}}
+
+	for _, test := range []struct {
+		game    *Game
+		options *SVGOptions
+		expect  string
+	}{
+		{&Game{Grid: [][]int{{1}}}, nil, "grid has 1 rows"},
+		{badValue, &SVGOptions{PencilMarks: true}, "value 9 at row 3 column 3"},
+		{badGiven, nil, "given has 1 rows"},
+	} {
+		var buf bytes.Buffer
+		var err error = WriteSVG(&buf, test.game, test.options)
+		assert.Contains(t, err.Error(), test.expect)
+		assert.Equal(t, 0, buf.Len())
+	}
+}
+
+func wellFormed(document string) bool {
+	var decoder *xml.Decoder = xml.NewDecoder(strings.NewReader(document))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
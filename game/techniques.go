@@ -0,0 +1,472 @@
+package game
+
+/*
+The techniques of the LogicalSolver.  Their difficulties follow the Sudoku Explainer ratings: a
+hidden single is 1.5, a naked single 2.3, and so on up to 5.4 for a hidden quad.
+*/
+var (
+	HiddenSingle             = Technique{Name: "Hidden Single", Difficulty: 15, find: findHiddenSingle}
+	NakedSingle              = Technique{Name: "Naked Single", Difficulty: 23, find: findNakedSingle}
+	LockedCandidatesPointing = Technique{Name: "Locked Candidates (Pointing)", Difficulty: 26, find: findPointing}
+	LockedCandidatesClaiming = Technique{Name: "Locked Candidates (Claiming)", Difficulty: 28, find: findClaiming}
+	NakedPair                = Technique{Name: "Naked Pair", Difficulty: 30, find: nakedSubset(2)}
+	XWing                    = Technique{Name: "X-Wing", Difficulty: 32, find: fish(2)}
+	HiddenPair               = Technique{Name: "Hidden Pair", Difficulty: 34, find: hiddenSubset(2)}
+	NakedTriple              = Technique{Name: "Naked Triple", Difficulty: 36, find: nakedSubset(3)}
+	Swordfish                = Technique{Name: "Swordfish", Difficulty: 38, find: fish(3)}
+	HiddenTriple             = Technique{Name: "Hidden Triple", Difficulty: 40, find: hiddenSubset(3)}
+	XYWing                   = Technique{Name: "XY-Wing", Difficulty: 42, find: findXYWing}
+	XYZWing                  = Technique{Name: "XYZ-Wing", Difficulty: 44, find: findXYZWing}
+	NakedQuad                = Technique{Name: "Naked Quad", Difficulty: 50, find: nakedSubset(4)}
+	Jellyfish                = Technique{Name: "Jellyfish", Difficulty: 52, find: fish(4)}
+	HiddenQuad               = Technique{Name: "Hidden Quad", Difficulty: 54, find: hiddenSubset(4)}
+)
+
+/*
+DefaultTechniques returns every technique, easiest first.
+*/
+func DefaultTechniques() []Technique {
+	return []Technique{
+		HiddenSingle,
+		NakedSingle,
+		LockedCandidatesPointing,
+		LockedCandidatesClaiming,
+		NakedPair,
+		XWing,
+		HiddenPair,
+		NakedTriple,
+		Swordfish,
+		HiddenTriple,
+		XYWing,
+		XYZWing,
+		UniqueRectangleType1,
+		AvoidableRectangleType1,
+		UniqueRectangleType2,
+		UniqueRectangleType4,
+		AvoidableRectangleType2,
+		UniqueRectangleType3,
+		UniqueRectangleType5,
+		UniqueRectangleType6,
+		NakedQuad,
+		Jellyfish,
+		HiddenQuad,
+		BUGPlusOne,
+		SimpleColoring,
+		MultiColoring,
+		XChain,
+		XYChain,
+		AlternatingInferenceChain,
+		ALSXZ,
+		ALSXYWing,
+		DeathBlossom,
+	}
+}
+
+/*
+findHiddenSingle looks for a value with one cell left in a sub grid, row or column, trying the sub
+grids first as they are the easiest to spot.
+*/
+func findHiddenSingle(grid *candidateGrid) *Step {
+	for i := 0; i < numUnits; i++ {
+		var unit int = (numRows + numColumns + i) % numUnits
+		for value := 0; value < numCandidates; value++ {
+			var cells []Cell = grid.cellsWith(unit, value)
+			if len(cells) == 1 {
+				return &Step{
+					Cells:      cells,
+					Digits:     []int{value + 1},
+					Placements: []CellDigit{{Cell: cells[0], Digit: value + 1}},
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+findNakedSingle looks for a cell with one candidate left.
+*/
+func findNakedSingle(grid *candidateGrid) *Step {
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var cell Cell = Cell{Row: row, Column: column}
+			if grid.get(cell).count() == 1 {
+				var value int = grid.get(cell).first()
+				return &Step{
+					Cells:      []Cell{cell},
+					Digits:     []int{value + 1},
+					Placements: []CellDigit{{Cell: cell, Digit: value + 1}},
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+findPointing looks for a value whose cells in a sub grid all lie in one row or column, which rules
+the value out of the rest of that row or column.
+*/
+func findPointing(grid *candidateGrid) *Step {
+	for unit := numRows + numColumns; unit < numUnits; unit++ {
+		if step := lockedCandidates(grid, unit, true); step != nil {
+			return step
+		}
+	}
+
+	return nil
+}
+
+/*
+findClaiming looks for a value whose cells in a row or column all lie in one sub grid, which rules
+the value out of the rest of that sub grid.
+*/
+func findClaiming(grid *candidateGrid) *Step {
+	for unit := 0; unit < numRows+numColumns; unit++ {
+		if step := lockedCandidates(grid, unit, false); step != nil {
+			return step
+		}
+	}
+
+	return nil
+}
+
+/*
+lockedCandidates looks in unit for a value confined to the intersection with another unit, a line
+when pointing and a sub grid when not, and removes the value from the rest of the other unit.
+*/
+func lockedCandidates(grid *candidateGrid, unit int, pointing bool) *Step {
+	for value := 0; value < numCandidates; value++ {
+		var cells []Cell = grid.cellsWith(unit, value)
+		if len(cells) < 2 {
+			continue
+		}
+		for _, other := range commonUnits(cells) {
+			if other == unit || (other >= numRows+numColumns) == pointing {
+				continue
+			}
+			var eliminations []CellDigit = grid.eliminations(value, cellsExcept(units[other][:], cells))
+			if len(eliminations) > 0 {
+				return &Step{Cells: cells, Digits: []int{value + 1}, Eliminations: eliminations}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+nakedSubset finds size cells of a unit whose candidates, taken together, are size values.  Those
+values have to go in those cells, so they come out of the rest of the unit.
+*/
+func nakedSubset(size int) func(grid *candidateGrid) *Step {
+	return func(grid *candidateGrid) *Step {
+		for unit := 0; unit < numUnits; unit++ {
+			var cells []Cell = make([]Cell, 0, numCandidates)
+			for _, cell := range units[unit] {
+				if count := grid.get(cell).count(); count >= 2 && count <= size {
+					cells = append(cells, cell)
+				}
+			}
+
+			var step *Step
+			combinations(len(cells), size, func(indexes []int) bool {
+				var subset []Cell = make([]Cell, size)
+				var union digitSet = 0
+				for i, index := range indexes {
+					subset[i] = cells[index]
+					union |= grid.get(cells[index])
+				}
+				if union.count() != size {
+					return false
+				}
+
+				var eliminations []CellDigit
+				for _, value := range union.values() {
+					eliminations = append(eliminations, grid.eliminations(value, cellsExcept(units[unit][:], subset))...)
+				}
+				if len(eliminations) > 0 {
+					step = &Step{Cells: subset, Digits: digits(union), Eliminations: eliminations}
+				}
+				return step != nil
+			})
+			if step != nil {
+				return step
+			}
+		}
+
+		return nil
+	}
+}
+
+/*
+hiddenSubset finds size values of a unit that, taken together, have only size cells left.  Those
+cells have to hold those values, so every other candidate comes out of them.
+*/
+func hiddenSubset(size int) func(grid *candidateGrid) *Step {
+	return func(grid *candidateGrid) *Step {
+		for unit := 0; unit < numUnits; unit++ {
+			var values []int = make([]int, 0, numCandidates)
+			var positions [numCandidates]digitSet
+			for value := 0; value < numCandidates; value++ {
+				for i, cell := range units[unit] {
+					if grid.get(cell).has(value) {
+						positions[value] = positions[value].add(i)
+					}
+				}
+				if count := positions[value].count(); count >= 2 && count <= size {
+					values = append(values, value)
+				}
+			}
+
+			var step *Step
+			combinations(len(values), size, func(indexes []int) bool {
+				var subset digitSet = 0
+				var union digitSet = 0
+				for _, index := range indexes {
+					subset = subset.add(values[index])
+					union |= positions[values[index]]
+				}
+				if union.count() != size {
+					return false
+				}
+
+				var cells []Cell = make([]Cell, 0, size)
+				var eliminations []CellDigit
+				for _, i := range union.values() {
+					var cell Cell = units[unit][i]
+					cells = append(cells, cell)
+					for _, value := range (grid.get(cell) &^ subset).values() {
+						eliminations = append(eliminations, CellDigit{Cell: cell, Digit: value + 1})
+					}
+				}
+				if len(eliminations) > 0 {
+					step = &Step{Cells: cells, Digits: digits(subset), Eliminations: eliminations}
+				}
+				return step != nil
+			})
+			if step != nil {
+				return step
+			}
+		}
+
+		return nil
+	}
+}
+
+/*
+fish finds a value whose cells in size rows lie in size columns, or the other way round: X-Wing,
+Swordfish and Jellyfish.  Each of the rows needs the value in one of the columns, so the columns
+hold it in those rows and nowhere else.
+*/
+func fish(size int) func(grid *candidateGrid) *Step {
+	return func(grid *candidateGrid) *Step {
+		for value := 0; value < numCandidates; value++ {
+			for _, byRow := range []bool{true, false} {
+				var cellAt = func(line, cross int) Cell {
+					if byRow {
+						return Cell{Row: line, Column: cross}
+					}
+					return Cell{Row: cross, Column: line}
+				}
+
+				var lines []int = make([]int, 0, numRows)
+				var crosses [numRows]digitSet
+				for line := 0; line < numRows; line++ {
+					for cross := 0; cross < numColumns; cross++ {
+						if grid.get(cellAt(line, cross)).has(value) {
+							crosses[line] = crosses[line].add(cross)
+						}
+					}
+					if count := crosses[line].count(); count >= 2 && count <= size {
+						lines = append(lines, line)
+					}
+				}
+
+				var step *Step
+				combinations(len(lines), size, func(indexes []int) bool {
+					var base digitSet = 0
+					var union digitSet = 0
+					for _, index := range indexes {
+						base = base.add(lines[index])
+						union |= crosses[lines[index]]
+					}
+					if union.count() != size {
+						return false
+					}
+
+					var cells []Cell
+					var others []Cell
+					for line := 0; line < numRows; line++ {
+						for _, cross := range union.values() {
+							if base.has(line) {
+								if grid.get(cellAt(line, cross)).has(value) {
+									cells = append(cells, cellAt(line, cross))
+								}
+							} else {
+								others = append(others, cellAt(line, cross))
+							}
+						}
+					}
+					var eliminations []CellDigit = grid.eliminations(value, others)
+					if len(eliminations) > 0 {
+						step = &Step{Cells: cells, Digits: []int{value + 1}, Eliminations: eliminations}
+					}
+					return step != nil
+				})
+				if step != nil {
+					return step
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+/*
+findXYWing looks for a pivot cell with candidates xy that sees two cells with xz and yz.  Whichever
+value the pivot takes, one of the two holds z, so z comes out of every cell that sees both.
+*/
+func findXYWing(grid *candidateGrid) *Step {
+	return wing(grid, 2, func(pivot, a, b digitSet) bool {
+		return a.count() == 2 && b.count() == 2 && (a&pivot).count() == 1 && (b&pivot).count() == 1 &&
+			a&pivot != b&pivot && a&^pivot == b&^pivot
+	})
+}
+
+/*
+findXYZWing looks for a pivot cell with candidates xyz that sees two cells with xz and yz.  One of
+the three holds z, so z comes out of every cell that sees all of them.
+*/
+func findXYZWing(grid *candidateGrid) *Step {
+	return wing(grid, 3, func(pivot, a, b digitSet) bool {
+		return a.count() == 2 && b.count() == 2 && a != b && a|b == pivot
+	})
+}
+
+/*
+wing tries every pivot with pivotSize candidates and every two of its peers that match, removing
+the one value the two peers share from the cells that see both of them, and the pivot too when it
+holds that value.
+*/
+func wing(grid *candidateGrid, pivotSize int, match func(pivot, a, b digitSet) bool) *Step {
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			var pivot Cell = Cell{Row: row, Column: column}
+			var pivotCandidates digitSet = grid.get(pivot)
+			if pivotCandidates.count() != pivotSize {
+				continue
+			}
+
+			var wings []Cell = make([]Cell, 0, 20)
+			for _, peer := range peers[row*numColumns+column] {
+				if grid.get(peer).count() == 2 && (grid.get(peer)&pivotCandidates).count() > 0 {
+					wings = append(wings, peer)
+				}
+			}
+
+			for i := 0; i < len(wings); i++ {
+				for j := i + 1; j < len(wings); j++ {
+					var a, b digitSet = grid.get(wings[i]), grid.get(wings[j])
+					if !match(pivotCandidates, a, b) {
+						continue
+					}
+					var value int = (a & b).first()
+					var targets []Cell
+					for _, cell := range peers[wings[i].Row*numColumns+wings[i].Column] {
+						if cell != pivot && sees(cell, wings[j]) && (!pivotCandidates.has(value) || sees(cell, pivot)) {
+							targets = append(targets, cell)
+						}
+					}
+					var eliminations []CellDigit = grid.eliminations(value, targets)
+					if len(eliminations) > 0 {
+						return &Step{
+							Cells:        []Cell{pivot, wings[i], wings[j]},
+							Digits:       digits(pivotCandidates | a | b),
+							Eliminations: eliminations,
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+combinations calls visit with every choice of size indexes below n, in increasing order, until
+visit returns true.
+*/
+func combinations(n, size int, visit func(indexes []int) bool) {
+	if size > n || size <= 0 {
+		return
+	}
+
+	var indexes []int = make([]int, size)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	for {
+		if visit(indexes) {
+			return
+		}
+		var i int = size - 1
+		for i >= 0 && indexes[i] == n-size+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indexes[i]++
+		for j := i + 1; j < size; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+/*
+commonUnits returns the units that hold every one of cells.
+*/
+func commonUnits(cells []Cell) []int {
+	var ret []int = make([]int, 0, 3)
+	var first Cell = cells[0]
+	var row, column, subGrid bool = true, true, true
+	for _, cell := range cells[1:] {
+		row = row && cell.Row == first.Row
+		column = column && cell.Column == first.Column
+		subGrid = subGrid && subGridIndex(cell.Row, cell.Column) == subGridIndex(first.Row, first.Column)
+	}
+	if row {
+		ret = append(ret, first.Row)
+	}
+	if column {
+		ret = append(ret, numRows+first.Column)
+	}
+	if subGrid {
+		ret = append(ret, numRows+numColumns+subGridIndex(first.Row, first.Column))
+	}
+
+	return ret
+}
+
+/*
+cellsExcept returns the cells of all that are not in except.
+*/
+func cellsExcept(all []Cell, except []Cell) []Cell {
+	var ret []Cell = make([]Cell, 0, len(all))
+	for _, cell := range all {
+		var skip bool = false
+		for _, other := range except {
+			skip = skip || cell == other
+		}
+		if !skip {
+			ret = append(ret, cell)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+openCandidateGrid returns an empty board where every cell still has every candidate, for the tests
+to carve patterns into.
+*/
+func openCandidateGrid() *candidateGrid {
+	var grid *candidateGrid = &candidateGrid{}
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			grid.values[row][column] = NotSet
+			grid.candidates[row][column] = allDigits
+		}
+	}
+
+	return grid
+}
+
+/*
+confine leaves value as a candidate of line, a row when byRow and a column otherwise, only at the
+given crosses.
+*/
+func confine(grid *candidateGrid, value int, line int, byRow bool, crosses ...int) {
+	var keep digitSet = digitSetOf(crosses...)
+	for cross := 0; cross < numColumns; cross++ {
+		var cell Cell = Cell{Row: line, Column: cross}
+		if !byRow {
+			cell = Cell{Row: cross, Column: line}
+		}
+		if !keep.has(cross) {
+			grid.candidates[cell.Row][cell.Column] = grid.get(cell).remove(value)
+		}
+	}
+}
+
+func eliminatedCells(step *Step) []Cell {
+	var ret []Cell = make([]Cell, 0)
+	for _, elimination := range step.Eliminations {
+		ret = append(ret, elimination.Cell)
+	}
+
+	return ret
+}
+
+func Test_findSingles(t *testing.T) {
+	var grid *candidateGrid = openCandidateGrid()
+	assert.Nil(t, findNakedSingle(grid))
+	assert.Nil(t, findHiddenSingle(grid))
+
+	grid.candidates[4][4] = digitSetOf(3)
+	var step *Step = findNakedSingle(grid)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 4, Column: 4}, Digit: 4}}, step.Placements)
+
+	grid = openCandidateGrid()
+	for _, cell := range units[numRows+numColumns] {
+		if cell != (Cell{Row: 1, Column: 1}) {
+			grid.candidates[cell.Row][cell.Column] = grid.get(cell).remove(2)
+		}
+	}
+	step = findHiddenSingle(grid)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 1, Column: 1}, Digit: 3}}, step.Placements)
+
+	grid.apply(step)
+	assert.Equal(t, 2, grid.values[1][1])
+	assert.False(t, grid.get(Cell{Row: 1, Column: 8}).has(2))
+	assert.False(t, grid.get(Cell{Row: 8, Column: 1}).has(2))
+	assert.True(t, grid.get(Cell{Row: 8, Column: 8}).has(2))
+}
+
+func Test_findLockedCandidates(t *testing.T) {
+	/*
+		1 is left in sub grid 0 only in row 0, so it comes out of the rest of row 0.
+	*/
+	var grid *candidateGrid = openCandidateGrid()
+	for _, cell := range units[numRows+numColumns] {
+		if cell.Row != 0 {
+			grid.candidates[cell.Row][cell.Column] = grid.get(cell).remove(0)
+		}
+	}
+	assert.Nil(t, findClaiming(openCandidateGrid()))
+	var step *Step = findPointing(grid)
+	assert.Len(t, step.Cells, 3)
+	assert.Equal(t, []int{1}, step.Digits)
+	assert.Equal(t, cellsExcept(units[0][:], units[0][:3]), eliminatedCells(step))
+
+	/*
+		1 is left in row 0 only in sub grid 0, so it comes out of the rest of sub grid 0.
+	*/
+	grid = openCandidateGrid()
+	confine(grid, 0, 0, true, 0, 1)
+	step = findClaiming(grid)
+	assert.Equal(t, []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}}, step.Cells)
+	assert.Len(t, step.Eliminations, 6)
+	for _, cell := range eliminatedCells(step) {
+		assert.NotEqual(t, 0, cell.Row)
+		assert.Equal(t, 0, subGridIndex(cell.Row, cell.Column))
+	}
+}
+
+func Test_nakedSubset(t *testing.T) {
+	var grid *candidateGrid = openCandidateGrid()
+	grid.candidates[0][0] = digitSetOf(0, 1)
+	grid.candidates[0][1] = digitSetOf(1, 2)
+	grid.candidates[0][2] = digitSetOf(0, 2)
+
+	assert.Nil(t, nakedSubset(2)(grid))
+	var step *Step = nakedSubset(3)(grid)
+	assert.Equal(t, []int{1, 2, 3}, step.Digits)
+	assert.Equal(t, []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}, {Row: 0, Column: 2}}, step.Cells)
+	assert.Len(t, step.Eliminations, 3*6)
+
+	/*
+		The three cells share sub grid 0 as well as row 0.
+	*/
+	grid.apply(step)
+	step = nakedSubset(3)(grid)
+	assert.Len(t, step.Eliminations, 3*6)
+	for _, cell := range eliminatedCells(step) {
+		assert.Equal(t, 0, subGridIndex(cell.Row, cell.Column))
+	}
+	grid.apply(step)
+	assert.Nil(t, nakedSubset(3)(grid))
+}
+
+func Test_hiddenSubset(t *testing.T) {
+	var grid *candidateGrid = openCandidateGrid()
+	confine(grid, 0, 0, true, 0, 1)
+	confine(grid, 1, 0, true, 0, 1)
+
+	var step *Step = hiddenSubset(2)(grid)
+	assert.Equal(t, []int{1, 2}, step.Digits)
+	assert.Equal(t, []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}}, step.Cells)
+	assert.Len(t, step.Eliminations, 2*7)
+
+	grid = openCandidateGrid()
+	for value := 0; value < 4; value++ {
+		confine(grid, value, 0, true, 0, 1, 2, 3)
+	}
+	assert.Nil(t, hiddenSubset(3)(grid))
+	step = hiddenSubset(4)(grid)
+	assert.Equal(t, []int{1, 2, 3, 4}, step.Digits)
+	assert.Len(t, step.Eliminations, 4*5)
+}
+
+func Test_fish(t *testing.T) {
+	for _, test := range []struct {
+		size    int
+		crosses [][]int
+	}{
+		{2, [][]int{{2, 7}, nil, nil, nil, {2, 7}}},
+		{3, [][]int{{1, 4}, nil, nil, nil, {4, 7}, nil, nil, nil, {1, 7}}},
+		{4, [][]int{{0, 1}, nil, {1, 2}, nil, {2, 3}, nil, {3, 0}}},
+	} {
+		for _, byRow := range []bool{true, false} {
+			var grid *candidateGrid = openCandidateGrid()
+			for line, crosses := range test.crosses {
+				if crosses != nil {
+					confine(grid, 5, line, byRow, crosses...)
+				}
+			}
+
+			assert.Nil(t, fish(test.size-1)(grid))
+			var step *Step = fish(test.size)(grid)
+			assert.Equal(t, []int{6}, step.Digits)
+			assert.Len(t, step.Cells, 2*test.size)
+			assert.Len(t, step.Eliminations, (numRows-test.size)*test.size)
+			for _, cell := range eliminatedCells(step) {
+				var line int = cell.Row
+				if !byRow {
+					line = cell.Column
+				}
+				assert.True(t, line >= len(test.crosses) || test.crosses[line] == nil)
+			}
+		}
+	}
+}
+
+func Test_findXYWing(t *testing.T) {
+	var grid *candidateGrid = openCandidateGrid()
+	grid.candidates[0][0] = digitSetOf(0, 1)
+	grid.candidates[0][4] = digitSetOf(0, 2)
+	grid.candidates[4][0] = digitSetOf(1, 2)
+
+	var step *Step = findXYWing(grid)
+	assert.Equal(t, []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 4}, {Row: 4, Column: 0}}, step.Cells)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 4, Column: 4}, Digit: 3}}, step.Eliminations)
+	assert.Nil(t, findXYZWing(grid))
+}
+
+func Test_findXYZWing(t *testing.T) {
+	var grid *candidateGrid = openCandidateGrid()
+	grid.candidates[0][0] = digitSetOf(0, 1, 2)
+	grid.candidates[0][5] = digitSetOf(0, 2)
+	grid.candidates[1][1] = digitSetOf(1, 2)
+
+	var step *Step = findXYZWing(grid)
+	assert.Equal(t, []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 5}, {Row: 1, Column: 1}}, step.Cells)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 0, Column: 1}, Digit: 3}, {Cell: Cell{Row: 0, Column: 2}, Digit: 3}},
+		step.Eliminations)
+	assert.Nil(t, findXYWing(grid))
+}
+
+func Test_combinations(t *testing.T) {
+	var seen [][]int = make([][]int, 0)
+	combinations(4, 2, func(indexes []int) bool {
+		seen = append(seen, append([]int{}, indexes...))
+		return false
+	})
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}, seen)
+
+	var count int = 0
+	combinations(9, 3, func([]int) bool {
+		count++
+		return count == 5
+	})
+	assert.Equal(t, 5, count)
+	combinations(2, 3, func([]int) bool {
+		t.Fail()
+		return true
+	})
+}
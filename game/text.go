@@ -0,0 +1,344 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const ansiReset string = "\x1b[0m"
+const ansiGiven string = "\x1b[1m"
+const ansiFilled string = "\x1b[36m"
+const ansiConflict string = "\x1b[1;31m"
+const ansiCandidate string = "\x1b[2m"
+
+/*
+TextOptions controls how WriteText draws a game.  The zero value draws Unicode box borders without
+color.
+*/
+type TextOptions struct {
+	/*
+		ASCII draws the borders with '+', '-' and '|' instead of Unicode box drawing characters.
+	*/
+	ASCII bool
+	/*
+		Color marks givens bold, filled cells cyan and cells that repeat a value in a row, column or
+		sub grid red, using ANSI escape codes.
+	*/
+	Color bool
+	/*
+		PencilMarks draws every cell as a 3x3 block, 27x27 in all, showing the remaining candidates of
+		the empty cells.
+	*/
+	PencilMarks bool
+	/*
+		Candidates overrides the pencil marks in the same way as SVGOptions.Candidates.
+	*/
+	Candidates [][][]int
+}
+
+/*
+boxCharacters are the pieces of the borders.  Junctions are indexed by [row][column] where 0 is
+the top or left edge, 1 an inner line and 2 the bottom or right edge.  The heavy set draws the sub
+grid borders and the light set the lines between cells in the pencil mark view.
+*/
+type boxCharacters struct {
+	heavyHorizontal string
+	lightHorizontal string
+	heavyVertical   string
+	lightVertical   string
+	/*
+		junctions[heavy horizontal][heavy vertical][row][column]
+	*/
+	junctions [2][2][3][3]string
+}
+
+var unicodeBox boxCharacters = boxCharacters{
+	heavyHorizontal: "━",
+	lightHorizontal: "─",
+	heavyVertical:   "┃",
+	lightVertical:   "│",
+	junctions: [2][2][3][3]string{
+		{
+			{{"┏", "┯", "┓"}, {"┠", "┼", "┨"}, {"┗", "┷", "┛"}},
+			{{"┏", "┳", "┓"}, {"┠", "╂", "┨"}, {"┗", "┻", "┛"}},
+		},
+		{
+			{{"┏", "┯", "┓"}, {"┣", "┿", "┫"}, {"┗", "┷", "┛"}},
+			{{"┏", "┳", "┓"}, {"┣", "╋", "┫"}, {"┗", "┻", "┛"}},
+		},
+	},
+}
+
+var asciiBox boxCharacters = boxCharacters{
+	heavyHorizontal: "-",
+	lightHorizontal: " ",
+	heavyVertical:   "|",
+	lightVertical:   " ",
+	junctions: [2][2][3][3]string{
+		{
+			{{"+", "-", "+"}, {"|", " ", "|"}, {"+", "-", "+"}},
+			{{"+", "+", "+"}, {"|", "|", "|"}, {"+", "+", "+"}},
+		},
+		{
+			{{"+", "-", "+"}, {"+", "-", "+"}, {"+", "-", "+"}},
+			{{"+", "+", "+"}, {"+", "+", "+"}, {"+", "+", "+"}},
+		},
+	},
+}
+
+/*
+FormatText returns game drawn as text, see WriteText.
+*/
+func FormatText(game *Game, options *TextOptions) (string, error) {
+	var buf bytes.Buffer
+	var err error = WriteText(&buf, game, options)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+/*
+WriteText draws game with borders around the sub grids, laid out as in the Sub Grids comment of
+model.go.  With PencilMarks every cell is drawn as a 3x3 block holding its candidates.
+
+WriteText does not panic on a malformed game or options: a grid or Given that is not 9 by 9, a value
+outside 0-8 or NotSet, or Candidates that are not 9 by 9 or hold a value outside 0-8 is an error,
+and nothing is written.
+*/
+func WriteText(w io.Writer, game *Game, options *TextOptions) error {
+	if game == nil {
+		return errors.New("game is nil on call to WriteText")
+	}
+	if options == nil {
+		options = &TextOptions{}
+	}
+	if err := checkDrawable(game, options.Candidates); err != nil {
+		return err
+	}
+
+	var box *boxCharacters = &unicodeBox
+	if options.ASCII {
+		box = &asciiBox
+	}
+
+	var conflicts [][]bool = conflictingCells(game)
+	var buf bytes.Buffer
+	if options.PencilMarks {
+		writePencilMarkText(&buf, game, options, box, conflicts)
+	} else {
+		writeCompactText(&buf, game, options, box, conflicts)
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+/*
+checkDrawable returns an error for the first part of game or candidates, the Candidates option of
+WriteText or WriteSVG, that cannot be drawn.
+*/
+func checkDrawable(game *Game, candidates [][][]int) error {
+	if len(game.Grid) != numRows {
+		return errors.New(fmt.Sprintf("grid has %d rows, expected %d", len(game.Grid), numRows))
+	}
+	if game.Given != nil && len(game.Given) != numRows {
+		return errors.New(fmt.Sprintf("given has %d rows, expected %d", len(game.Given), numRows))
+	}
+	if candidates != nil && len(candidates) != numRows {
+		return errors.New(fmt.Sprintf("candidates have %d rows, expected %d", len(candidates), numRows))
+	}
+
+	for row := 0; row < numRows; row++ {
+		if len(game.Grid[row]) != numColumns {
+			return errors.New(fmt.Sprintf("grid row %d has %d columns, expected %d", row, len(game.Grid[row]), numColumns))
+		}
+		if game.Given != nil && len(game.Given[row]) != numColumns {
+			return errors.New(fmt.Sprintf("given row %d has %d columns, expected %d", row, len(game.Given[row]), numColumns))
+		}
+		if candidates != nil && len(candidates[row]) != numColumns {
+			return errors.New(fmt.Sprintf("candidate row %d has %d columns, expected %d",
+				row, len(candidates[row]), numColumns))
+		}
+		for column := 0; column < numColumns; column++ {
+			if value := game.Grid[row][column]; value != NotSet && (value < 0 || value >= numCandidates) {
+				return errors.New(fmt.Sprintf("value %d at row %d column %d is out of range", value, row, column))
+			}
+			if candidates == nil {
+				continue
+			}
+			for _, candidate := range candidates[row][column] {
+				if candidate < 0 || candidate >= numCandidates {
+					return errors.New(fmt.Sprintf("candidate %d at row %d column %d is out of range", candidate, row, column))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeCompactText(buf *bytes.Buffer, game *Game, options *TextOptions, box *boxCharacters, conflicts [][]bool) {
+	var border = func(edge int) {
+		for column := 0; column < numColumns; column += subGridColumns {
+			var position int = 1
+			if column == 0 {
+				position = 0
+			}
+			buf.WriteString(box.junctions[1][1][edge][position])
+			buf.WriteString(strings.Repeat(box.heavyHorizontal, 2*subGridColumns+1))
+		}
+		buf.WriteString(box.junctions[1][1][edge][2])
+		buf.WriteString("\n")
+	}
+
+	var empty string = "·"
+	if options.ASCII {
+		empty = "."
+	}
+
+	border(0)
+	for row := 0; row < numRows; row++ {
+		if row != 0 && row%subGridRows == 0 {
+			border(1)
+		}
+		for column := 0; column < numColumns; column++ {
+			if column%subGridColumns == 0 {
+				if column != 0 {
+					buf.WriteString(" ")
+				}
+				buf.WriteString(box.heavyVertical)
+			}
+			buf.WriteString(" ")
+			var value int = game.Grid[row][column]
+			if value == NotSet {
+				buf.WriteString(empty)
+			} else {
+				buf.WriteString(colorCell(game, options, conflicts, row, column, fmt.Sprintf("%d", value+1)))
+			}
+		}
+		buf.WriteString(" " + box.heavyVertical + "\n")
+	}
+	border(2)
+}
+
+func writePencilMarkText(buf *bytes.Buffer, game *Game, options *TextOptions, box *boxCharacters, conflicts [][]bool) {
+	var border = func(row int) {
+		var edge int = 1
+		if row == 0 {
+			edge = 0
+		} else if row == numRows {
+			edge = 2
+		}
+		var heavyHorizontal int = 0
+		var horizontal string = box.lightHorizontal
+		if row%subGridRows == 0 {
+			heavyHorizontal = 1
+			horizontal = box.heavyHorizontal
+		}
+
+		for column := 0; column < numColumns; column++ {
+			var position int = 1
+			if column == 0 {
+				position = 0
+			}
+			var heavyVertical int = 0
+			if column%subGridColumns == 0 {
+				heavyVertical = 1
+			}
+			buf.WriteString(box.junctions[heavyHorizontal][heavyVertical][edge][position])
+			buf.WriteString(strings.Repeat(horizontal, subGridColumns))
+		}
+		buf.WriteString(box.junctions[heavyHorizontal][1][edge][2])
+		buf.WriteString("\n")
+	}
+
+	for row := 0; row < numRows; row++ {
+		border(row)
+		for line := 0; line < subGridRows; line++ {
+			for column := 0; column < numColumns; column++ {
+				if column%subGridColumns == 0 {
+					buf.WriteString(box.heavyVertical)
+				} else {
+					buf.WriteString(box.lightVertical)
+				}
+				buf.WriteString(pencilMarkLine(game, options, conflicts, row, column, line))
+			}
+			buf.WriteString(box.heavyVertical + "\n")
+		}
+	}
+	border(numRows)
+}
+
+/*
+pencilMarkLine returns one of the three lines of a cell in the pencil mark view.  A set cell shows
+its value in the middle, an empty cell shows each candidate in its own position of a 3x3 block.
+*/
+func pencilMarkLine(game *Game, options *TextOptions, conflicts [][]bool, row, column, line int) string {
+	var value int = game.Grid[row][column]
+	if value != NotSet {
+		if line != 1 {
+			return "   "
+		}
+		return " " + colorCell(game, options, conflicts, row, column, fmt.Sprintf("%d", value+1)) + " "
+	}
+
+	var candidates []int
+	if options.Candidates != nil {
+		candidates = options.Candidates[row][column]
+	} else {
+		candidates = game.Candidates(row, column)
+	}
+
+	var marks [subGridColumns]string = [subGridColumns]string{" ", " ", " "}
+	for _, candidate := range candidates {
+		if candidate/subGridColumns == line {
+			marks[candidate%subGridColumns] = fmt.Sprintf("%d", candidate+1)
+		}
+	}
+
+	var text string = strings.Join(marks[:], "")
+	if options.Color && strings.TrimSpace(text) != "" {
+		return ansiCandidate + text + ansiReset
+	}
+
+	return text
+}
+
+func colorCell(game *Game, options *TextOptions, conflicts [][]bool, row, column int, text string) string {
+	if !options.Color {
+		return text
+	}
+
+	switch {
+	case conflicts[row][column]:
+		return ansiConflict + text + ansiReset
+	case game.IsGiven(row, column):
+		return ansiGiven + text + ansiReset
+	default:
+		return ansiFilled + text + ansiReset
+	}
+}
+
+/*
+conflictingCells marks every set cell whose value appears more than once in its row, column or
+sub grid.
+*/
+func conflictingCells(game *Game) [][]bool {
+	var ret [][]bool = make([][]bool, numRows)
+	for row := 0; row < numRows; row++ {
+		ret[row] = make([]bool, numColumns)
+	}
+
+	for _, conflict := range ValidateGame(game) {
+		for _, cell := range conflict.Cells {
+			ret[cell.Row][cell.Column] = true
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatText(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	text, err := FormatText(game, &TextOptions{ASCII: true})
+	assert.Nil(t, err)
+	var lines []string = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	assert.Equal(t, 13, len(lines))
+	assert.Equal(t, "+-------+-------+-------+", lines[0])
+	assert.Equal(t, "| 5 3 . | . 7 . | . . . |", lines[1])
+	assert.Equal(t, "+-------+-------+-------+", lines[4])
+	assert.Equal(t, "| . . . | . 8 . | . 7 9 |", lines[11])
+
+	text, err = FormatText(game, nil)
+	assert.Nil(t, err)
+	lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	assert.Equal(t, "┏━━━━━━━┳━━━━━━━┳━━━━━━━┓", lines[0])
+	assert.Equal(t, "┃ 5 3 · ┃ · 7 · ┃ · · · ┃", lines[1])
+	assert.Equal(t, "┣━━━━━━━╋━━━━━━━╋━━━━━━━┫", lines[4])
+	assert.NotContains(t, text, "\x1b[")
+}
+
+func Test_FormatText_color(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	gs, err := createGame(game)
+	assert.Nil(t, err)
+	gs.addCandidate(&candidate{row: 0, column: 2, value: 3})
+	game = gs.toGame()
+
+	text, err := FormatText(game, &TextOptions{Color: true})
+	assert.Nil(t, err)
+	assert.Contains(t, text, ansiGiven+"5"+ansiReset)
+	assert.Contains(t, text, ansiFilled+"4"+ansiReset)
+	assert.NotContains(t, text, ansiConflict)
+
+	/* 5 twice in the top row */
+	game.Grid[0][2] = 4
+	text, err = FormatText(game, &TextOptions{Color: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(text, ansiConflict+"5"+ansiReset))
+}
+
+func Test_FormatText_pencilMarks(t *testing.T) {
+	game, err := ParseGame(easyPuzzle)
+	assert.Nil(t, err)
+
+	text, err := FormatText(game, &TextOptions{PencilMarks: true})
+	assert.Nil(t, err)
+	var lines []string = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	/* 27 lines of cells and 10 borders */
+	assert.Equal(t, 37, len(lines))
+	assert.Equal(t, "┏━━━┯━━━┯━━━┳━━━┯━━━┯━━━┳━━━┯━━━┯━━━┓", lines[0])
+	/* (0, 3) can be 2 or 6 */
+	assert.Equal(t, "┃   │   │12 ┃ 2 │", lines[1][:len("┃   │   │12 ┃ 2 │")])
+	assert.Equal(t, "┃ 5 │ 3 │4  ┃  6│", lines[2][:len("┃ 5 │ 3 │4  ┃  6│")])
+	assert.Equal(t, "┠───┼───┼───╂───┼───┼───╂───┼───┼───┨", lines[4])
+	assert.Equal(t, "┣━━━┿━━━┿━━━╋━━━┿━━━┿━━━╋━━━┿━━━┿━━━┫", lines[12])
+
+	var candidates [][][]int = make([][][]int, numRows)
+	for row := 0; row < numRows; row++ {
+		candidates[row] = make([][]int, numColumns)
+	}
+	text, err = FormatText(NewGame(), &TextOptions{PencilMarks: true, ASCII: true, Candidates: candidates})
+	assert.Nil(t, err)
+	assert.Equal(t, "", strings.Trim(text, "+-| \n"))
+
+}
+
+func TestWriteText_invalid(t *testing.T) {
+	var candidates [][][]int = make([][][]int, numRows)
+	for row := 0; row < numRows; row++ {
+		candidates[row] = make([][]int, numColumns)
+	}
+	var short [][][]int = append([][][]int{}, candidates...)
+	short[4] = short[4][:3]
+	var outOfRange [][][]int = append([][][]int{}, candidates...)
+	outOfRange[2] = append([][]int{{-1}}, candidates[2][1:]...)
+
+	var badValue *Game = NewGame()
+	badValue.Grid[3][3] = 9
+	var badGiven *Game = NewGame()
+	badGiven.Given = [][]bool{{true}}
+
+	for _, test := range []struct {
+		game    *Game
+		options *TextOptions
+		expect  string
+	}{
+		{NewGame(), &TextOptions{Candidates: candidates[:1]}, "candidates have 1 rows"},
+		{NewGame(), &TextOptions{PencilMarks: true, Candidates: short}, "candidate row 4 has 3 columns"},
+		{NewGame(), &TextOptions{PencilMarks: true, Candidates: outOfRange}, "candidate -1 at row 2 column 0"},
+		{&Game{Grid: NewGame().Grid[:8]}, nil, "grid has 8 rows"},
+		{badValue, &TextOptions{PencilMarks: true}, "value 9 at row 3 column 3"},
+		{badGiven, nil, "given has 1 rows"},
+	} {
+		var buf strings.Builder
+		var err error = WriteText(&buf, test.game, test.options)
+		assert.Contains(t, err.Error(), test.expect)
+		assert.Equal(t, "", buf.String())
+
+		text, err := FormatText(test.game, test.options)
+		assert.Contains(t, err.Error(), test.expect)
+		assert.Equal(t, "", text)
+	}
+
+	/*
+		Logging a board that cannot be drawn still shows what is in it.
+	*/
+	assert.Contains(t, badValue.String(), "9")
+}
@@ -0,0 +1,430 @@
+package game
+
+/*
+The uniqueness techniques rule out patterns that would leave the puzzle with two solutions, so they
+are only sound for puzzles with exactly one.  LogicalSolver.SkipUniqueness leaves them out.
+*/
+var (
+	UniqueRectangleType1    = Technique{Name: "Unique Rectangle Type 1", Difficulty: 45, RequiresUniqueness: true, find: uniqueRectangle(uniqueRectangleType1)}
+	UniqueRectangleType2    = Technique{Name: "Unique Rectangle Type 2", Difficulty: 46, RequiresUniqueness: true, find: uniqueRectangle(uniqueRectangleType2)}
+	UniqueRectangleType3    = Technique{Name: "Unique Rectangle Type 3", Difficulty: 47, RequiresUniqueness: true, find: uniqueRectangle(uniqueRectangleType3)}
+	UniqueRectangleType4    = Technique{Name: "Unique Rectangle Type 4", Difficulty: 46, RequiresUniqueness: true, find: uniqueRectangle(uniqueRectangleType4)}
+	UniqueRectangleType5    = Technique{Name: "Unique Rectangle Type 5", Difficulty: 47, RequiresUniqueness: true, find: uniqueRectangle(uniqueRectangleType5)}
+	UniqueRectangleType6    = Technique{Name: "Unique Rectangle Type 6", Difficulty: 47, RequiresUniqueness: true, find: uniqueRectangle(uniqueRectangleType6)}
+	AvoidableRectangleType1 = Technique{Name: "Avoidable Rectangle Type 1", Difficulty: 45, RequiresUniqueness: true, find: findAvoidableRectangleType1}
+	AvoidableRectangleType2 = Technique{Name: "Avoidable Rectangle Type 2", Difficulty: 46, RequiresUniqueness: true, find: findAvoidableRectangleType2}
+	BUGPlusOne              = Technique{Name: "BUG+1", Difficulty: 55, RequiresUniqueness: true, find: findBUGPlusOne}
+)
+
+/*
+rectangles calls visit with the corners of every rectangle of cells that spans two rows, two
+columns and exactly two sub grids, until visit returns a step.  The corners are top left, top
+right, bottom left and bottom right, so corner i shares a row with corner i^1, a column with corner
+i^2 and neither with corner 3-i.
+*/
+func rectangles(visit func(corners [4]Cell) *Step) *Step {
+	for top := 0; top < numRows; top++ {
+		for bottom := top + 1; bottom < numRows; bottom++ {
+			for left := 0; left < numColumns; left++ {
+				for right := left + 1; right < numColumns; right++ {
+					if (top/subGridRows == bottom/subGridRows) == (left/subGridColumns == right/subGridColumns) {
+						continue
+					}
+					var corners [4]Cell = [4]Cell{
+						{Row: top, Column: left}, {Row: top, Column: right},
+						{Row: bottom, Column: left}, {Row: bottom, Column: right},
+					}
+					if step := visit(corners); step != nil {
+						return step
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+uniqueRectangle tries every rectangle of empty cells and every two values all four corners share,
+the deadly pair, with match.  If the corners could only hold the pair, the two values could be
+swapped around the rectangle for a second solution, so one of the corners holds something else.
+*/
+func uniqueRectangle(match func(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step) func(grid *candidateGrid) *Step {
+	return func(grid *candidateGrid) *Step {
+		return rectangles(func(corners [4]Cell) *Step {
+			var common digitSet = allDigits
+			for _, corner := range corners {
+				if !grid.empty(corner) {
+					return nil
+				}
+				common &= grid.get(corner)
+			}
+			var values []int = common.values()
+			for i := 0; i < len(values); i++ {
+				for j := i + 1; j < len(values); j++ {
+					if step := match(grid, corners, digitSetOf(values[i], values[j])); step != nil {
+						return step
+					}
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+/*
+roof returns the corners with candidates besides the pair.
+*/
+func roof(grid *candidateGrid, corners [4]Cell, pair digitSet) []Cell {
+	var ret []Cell = make([]Cell, 0, len(corners))
+	for _, corner := range corners {
+		if grid.get(corner) != pair {
+			ret = append(ret, corner)
+		}
+	}
+
+	return ret
+}
+
+/*
+adjacent reports whether two corners share a row or column, rather than sitting across a diagonal.
+*/
+func adjacent(a, b Cell) bool {
+	return a.Row == b.Row || a.Column == b.Column
+}
+
+/*
+extras returns the candidates of cells besides the pair.
+*/
+func extras(grid *candidateGrid, cells []Cell, pair digitSet) digitSet {
+	var ret digitSet = 0
+	for _, cell := range cells {
+		ret |= grid.get(cell) &^ pair
+	}
+
+	return ret
+}
+
+/*
+seenByAll returns the cells, other than cells themselves, that see every one of cells.
+*/
+func seenByAll(cells []Cell) []Cell {
+	var ret []Cell = make([]Cell, 0, 20)
+	for _, peer := range peers[cells[0].Row*numColumns+cells[0].Column] {
+		var all bool = true
+		for _, cell := range cells[1:] {
+			all = all && sees(peer, cell)
+		}
+		if all {
+			ret = append(ret, peer)
+		}
+	}
+
+	return ret
+}
+
+/*
+rectangleStep returns the step for a rectangle, nil when it removes nothing.
+*/
+func rectangleStep(corners [4]Cell, extra []Cell, values digitSet, eliminations []CellDigit) *Step {
+	if len(eliminations) == 0 {
+		return nil
+	}
+
+	return &Step{
+		Cells:        append(corners[:len(corners):len(corners)], extra...),
+		Digits:       digits(values),
+		Eliminations: eliminations,
+	}
+}
+
+/*
+uniqueRectangleType1: three corners hold only the pair, so the fourth cannot hold either value.
+*/
+func uniqueRectangleType1(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step {
+	var cells []Cell = roof(grid, corners, pair)
+	if len(cells) != 1 {
+		return nil
+	}
+
+	var eliminations []CellDigit
+	for _, value := range pair.values() {
+		eliminations = append(eliminations, grid.eliminations(value, cells)...)
+	}
+
+	return rectangleStep(corners, nil, pair, eliminations)
+}
+
+/*
+uniqueRectangleType2: two corners on one side hold only the pair and the other two hold one more
+value, the same for both.  One of them holds it, so it comes out of every cell that sees both.
+*/
+func uniqueRectangleType2(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step {
+	var cells []Cell = roof(grid, corners, pair)
+	if len(cells) != 2 || !adjacent(cells[0], cells[1]) {
+		return nil
+	}
+
+	return sharedExtra(grid, corners, pair, cells)
+}
+
+/*
+uniqueRectangleType5: like type 2, but the corners with the one more value sit across a diagonal or
+there are three of them.
+*/
+func uniqueRectangleType5(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step {
+	var cells []Cell = roof(grid, corners, pair)
+	if len(cells) < 2 || len(cells) > 3 || len(cells) == 2 && adjacent(cells[0], cells[1]) {
+		return nil
+	}
+
+	return sharedExtra(grid, corners, pair, cells)
+}
+
+/*
+sharedExtra removes the one value cells hold besides the pair from the cells that see all of them.
+*/
+func sharedExtra(grid *candidateGrid, corners [4]Cell, pair digitSet, cells []Cell) *Step {
+	var extra digitSet = extras(grid, cells, pair)
+	if extra.count() != 1 {
+		return nil
+	}
+	for _, cell := range cells {
+		if grid.get(cell)&^pair != extra {
+			return nil
+		}
+	}
+
+	return rectangleStep(corners, nil, pair|extra, grid.eliminations(extra.first(), seenByAll(cells)))
+}
+
+/*
+uniqueRectangleType3: two corners on one side hold only the pair, so the other two hold at least
+one of their other values between them and act as one cell with those values.  Together with other
+cells of a unit they share they can make a naked subset, whose values come out of the rest of the
+unit.
+*/
+func uniqueRectangleType3(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step {
+	var cells []Cell = roof(grid, corners, pair)
+	if len(cells) != 2 || !adjacent(cells[0], cells[1]) {
+		return nil
+	}
+	var extra digitSet = extras(grid, cells, pair)
+
+	var step *Step
+	for _, unit := range commonUnits(cells) {
+		var others []Cell = make([]Cell, 0, numCandidates)
+		for _, cell := range cellsExcept(units[unit][:], cells) {
+			if grid.empty(cell) {
+				others = append(others, cell)
+			}
+		}
+		for size := 1; size < len(others) && step == nil; size++ {
+			combinations(len(others), size, func(indexes []int) bool {
+				var values digitSet = extra
+				var subset []Cell = make([]Cell, size)
+				for i, index := range indexes {
+					subset[i] = others[index]
+					values |= grid.get(others[index])
+				}
+				if values.count() != size+1 {
+					return false
+				}
+				var eliminations []CellDigit
+				var rest []Cell = cellsExcept(others, subset)
+				for _, value := range values.values() {
+					eliminations = append(eliminations, grid.eliminations(value, rest)...)
+				}
+				step = rectangleStep(corners, subset, pair|values, eliminations)
+				return step != nil
+			})
+		}
+		if step != nil {
+			return step
+		}
+	}
+
+	return nil
+}
+
+/*
+uniqueRectangleType4: two corners on one side hold only the pair and the other two share a unit in
+which one value of the pair has no other place.  One of the two holds that value, so neither can
+hold the other value of the pair without the rectangle closing.
+*/
+func uniqueRectangleType4(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step {
+	var cells []Cell = roof(grid, corners, pair)
+	if len(cells) != 2 || !adjacent(cells[0], cells[1]) {
+		return nil
+	}
+
+	for _, unit := range commonUnits(cells) {
+		for _, value := range pair.values() {
+			if len(grid.cellsWith(unit, value)) != 2 {
+				continue
+			}
+			var other int = pair.remove(value).first()
+			if step := rectangleStep(corners, nil, pair, grid.eliminations(other, cells)); step != nil {
+				return step
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+uniqueRectangleType6: two corners across a diagonal hold only the pair and one value of the pair
+has no place in both rows, or both columns, outside the rectangle.  Were the value in either of the
+other two corners, it would have to be in both and close the rectangle, so it comes out of them.
+*/
+func uniqueRectangleType6(grid *candidateGrid, corners [4]Cell, pair digitSet) *Step {
+	var cells []Cell = roof(grid, corners, pair)
+	if len(cells) != 2 || adjacent(cells[0], cells[1]) {
+		return nil
+	}
+
+	for _, value := range pair.values() {
+		var rows, columns bool = true, true
+		for _, corner := range []Cell{corners[0], corners[3]} {
+			for _, cell := range grid.cellsWith(corner.Row, value) {
+				rows = rows && (cell.Column == corners[0].Column || cell.Column == corners[3].Column)
+			}
+			for _, cell := range grid.cellsWith(numRows+corner.Column, value) {
+				columns = columns && (cell.Row == corners[0].Row || cell.Row == corners[3].Row)
+			}
+		}
+		if !rows && !columns {
+			continue
+		}
+		if step := rectangleStep(corners, nil, pair, grid.eliminations(value, cells)); step != nil {
+			return step
+		}
+	}
+
+	return nil
+}
+
+/*
+avoidable reports whether cell was solved along the way, rather than given.
+*/
+func (grid *candidateGrid) avoidable(cell Cell) bool {
+	return !grid.empty(cell) && !grid.given[cell.Row][cell.Column]
+}
+
+/*
+findAvoidableRectangleType1 looks for three corners solved along the way, two across a diagonal
+with the same value.  The fourth corner cannot take the value of the corner across from it, or the
+values of the rectangle could be swapped for a second solution.
+*/
+func findAvoidableRectangleType1(grid *candidateGrid) *Step {
+	return rectangles(func(corners [4]Cell) *Step {
+		for open, cell := range corners {
+			var across, side, other Cell = corners[3-open], corners[open^1], corners[open^2]
+			if !grid.empty(cell) || !grid.avoidable(across) || !grid.avoidable(side) || !grid.avoidable(other) {
+				continue
+			}
+			var value int = grid.values[across.Row][across.Column]
+			if grid.values[side.Row][side.Column] != grid.values[other.Row][other.Column] {
+				continue
+			}
+			var pair digitSet = digitSetOf(value, grid.values[side.Row][side.Column])
+			if step := rectangleStep(corners, nil, pair, grid.eliminations(value, []Cell{cell})); step != nil {
+				return step
+			}
+		}
+
+		return nil
+	})
+}
+
+/*
+findAvoidableRectangleType2 looks for two corners on one side solved along the way, with values a
+and b, and the two corners across from them holding b and a along with one more value, the same
+for both.  One of them holds that value, or the rectangle could be swapped for a second solution,
+so it comes out of every cell that sees both.
+*/
+func findAvoidableRectangleType2(grid *candidateGrid) *Step {
+	return rectangles(func(corners [4]Cell) *Step {
+		for _, side := range [][2]int{{0, 1}, {2, 3}, {0, 2}, {1, 3}} {
+			var a, b Cell = corners[side[0]], corners[side[1]]
+			if !grid.empty(a) || !grid.empty(b) {
+				continue
+			}
+			var across int = side[0] ^ side[1] ^ 3
+			var solvedA, solvedB Cell = corners[side[0]^across], corners[side[1]^across]
+			if !grid.avoidable(solvedA) || !grid.avoidable(solvedB) {
+				continue
+			}
+			var valueA, valueB int = grid.values[solvedA.Row][solvedA.Column], grid.values[solvedB.Row][solvedB.Column]
+			if !grid.get(a).has(valueB) || !grid.get(b).has(valueA) {
+				continue
+			}
+			var extra digitSet = grid.get(a).remove(valueB)
+			if extra.count() != 1 || grid.get(b).remove(valueA) != extra {
+				continue
+			}
+			var cells []Cell = []Cell{a, b}
+			var pair digitSet = digitSetOf(valueA, valueB)
+			if step := rectangleStep(corners, nil, pair|extra, grid.eliminations(extra.first(), seenByAll(cells))); step != nil {
+				return step
+			}
+		}
+
+		return nil
+	})
+}
+
+/*
+findBUGPlusOne looks for a board where every empty cell has two candidates but one, which has
+three, and every value is a candidate of two cells in each unit but the one value of that cell
+that is a candidate of three cells in each of its units, the cell among them.  Without that value
+the board would be a bivalue universal grave, which has two solutions or none, so the cell takes it.
+*/
+func findBUGPlusOne(grid *candidateGrid) *Step {
+	var triple Cell
+	var triples int = 0
+	for row := 0; row < numRows; row++ {
+		for column := 0; column < numColumns; column++ {
+			switch grid.candidates[row][column].count() {
+			case 0, 2:
+			case 3:
+				triple = Cell{Row: row, Column: column}
+				triples++
+			default:
+				return nil
+			}
+		}
+	}
+	if triples != 1 {
+		return nil
+	}
+
+	var value int = NotSet
+	for unit := 0; unit < numUnits; unit++ {
+		var holds bool = false
+		for _, cell := range units[unit] {
+			holds = holds || cell == triple
+		}
+		for candidate := 0; candidate < numCandidates; candidate++ {
+			switch count := len(grid.cellsWith(unit, candidate)); {
+			case count == 0 || count == 2:
+			case count == 3 && holds && (value == NotSet || value == candidate):
+				value = candidate
+			default:
+				return nil
+			}
+		}
+	}
+	if value == NotSet || !grid.get(triple).has(value) {
+		return nil
+	}
+
+	return &Step{
+		Cells:      []Cell{triple},
+		Digits:     digits(grid.get(triple)),
+		Placements: []CellDigit{{Cell: triple, Digit: value + 1}},
+	}
+}
@@ -0,0 +1,238 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+rectangleGrid returns an open board with the rectangle r1c1 r1c4 r2c1 r2c4 holding the candidates
+given, in corner order, for the unique rectangle tests.
+*/
+func rectangleGrid(candidates ...digitSet) *candidateGrid {
+	var grid *candidateGrid = openCandidateGrid()
+	for i, cell := range []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 3}, {Row: 1, Column: 0}, {Row: 1, Column: 3}} {
+		grid.candidates[cell.Row][cell.Column] = candidates[i]
+	}
+
+	return grid
+}
+
+/*
+eliminatedDigits returns the digits of the eliminations of step, each once.
+*/
+func eliminatedDigits(step *Step) []int {
+	var ret []int = make([]int, 0)
+	var seen digitSet = 0
+	for _, elimination := range step.Eliminations {
+		if !seen.has(elimination.Digit - 1) {
+			seen = seen.add(elimination.Digit - 1)
+			ret = append(ret, elimination.Digit)
+		}
+	}
+
+	return ret
+}
+
+func Test_uniqueRectangle(t *testing.T) {
+	var pair digitSet = digitSetOf(0, 1)
+	var row1 []Cell = []Cell{{Row: 1, Column: 1}, {Row: 1, Column: 2}, {Row: 1, Column: 4}, {Row: 1, Column: 5}, {Row: 1, Column: 6}, {Row: 1, Column: 7}, {Row: 1, Column: 8}}
+
+	var grid *candidateGrid = rectangleGrid(pair, pair, pair, digitSetOf(0, 1, 5))
+	var step *Step = UniqueRectangleType1.find(grid)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 1, Column: 3}, Digit: 1}, {Cell: Cell{Row: 1, Column: 3}, Digit: 2}}, step.Eliminations)
+	assert.Equal(t, []int{1, 2}, step.Digits)
+	assert.Len(t, step.Cells, 4)
+
+	grid = rectangleGrid(pair, pair, digitSetOf(0, 1, 5), digitSetOf(0, 1, 5))
+	step = UniqueRectangleType2.find(grid)
+	assert.Equal(t, row1, eliminatedCells(step))
+	assert.Equal(t, []int{6}, eliminatedDigits(step))
+	assert.Nil(t, UniqueRectangleType5.find(grid))
+
+	grid = rectangleGrid(pair, digitSetOf(0, 1, 5), digitSetOf(0, 1, 5), pair)
+	step = UniqueRectangleType5.find(grid)
+	assert.Equal(t, []Cell{{Row: 0, Column: 1}, {Row: 0, Column: 2}, {Row: 1, Column: 4}, {Row: 1, Column: 5}}, eliminatedCells(step))
+	assert.Equal(t, []int{6}, eliminatedDigits(step))
+	assert.Nil(t, UniqueRectangleType2.find(grid))
+
+	/*
+		The extra 6 and 7 of the bottom corners make a naked pair with r2c7.
+	*/
+	grid = rectangleGrid(pair, pair, digitSetOf(0, 1, 5), digitSetOf(0, 1, 6))
+	grid.candidates[1][6] = digitSetOf(5, 6)
+	step = UniqueRectangleType3.find(grid)
+	var rest []Cell = cellsExcept(row1, []Cell{{Row: 1, Column: 6}})
+	assert.Equal(t, append(rest, rest...), eliminatedCells(step))
+	assert.Equal(t, []int{6, 7}, eliminatedDigits(step))
+	assert.Contains(t, step.Cells, Cell{Row: 1, Column: 6})
+
+	/*
+		1 has no other place in row 2, so the bottom corners cannot hold 2.
+	*/
+	grid = rectangleGrid(pair, pair, digitSetOf(0, 1, 5), digitSetOf(0, 1, 6))
+	assert.Nil(t, UniqueRectangleType4.find(grid))
+	confine(grid, 0, 1, true, 0, 3)
+	step = UniqueRectangleType4.find(grid)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 1, Column: 0}, Digit: 2}, {Cell: Cell{Row: 1, Column: 3}, Digit: 2}}, step.Eliminations)
+
+	/*
+		1 has no other place in either row, so it comes out of the corners across from the pairs.
+	*/
+	grid = rectangleGrid(pair, digitSetOf(0, 1, 5), digitSetOf(0, 1, 6), pair)
+	assert.Nil(t, UniqueRectangleType6.find(grid))
+	confine(grid, 0, 0, true, 0, 3)
+	confine(grid, 0, 1, true, 0, 3)
+	step = UniqueRectangleType6.find(grid)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 0, Column: 3}, Digit: 1}, {Cell: Cell{Row: 1, Column: 0}, Digit: 1}}, step.Eliminations)
+
+	/*
+		A rectangle inside one sub grid is no deadly pattern.
+	*/
+	grid = openCandidateGrid()
+	for _, cell := range []Cell{{Row: 0, Column: 0}, {Row: 0, Column: 1}, {Row: 1, Column: 0}} {
+		grid.candidates[cell.Row][cell.Column] = pair
+	}
+	grid.candidates[1][1] = digitSetOf(0, 1, 5)
+	assert.Nil(t, UniqueRectangleType1.find(grid))
+}
+
+func Test_avoidableRectangle(t *testing.T) {
+	var grid *candidateGrid = openCandidateGrid()
+	for _, placement := range []CellDigit{{Cell: Cell{Row: 0, Column: 0}, Digit: 1}, {Cell: Cell{Row: 0, Column: 3}, Digit: 2}, {Cell: Cell{Row: 1, Column: 0}, Digit: 2}} {
+		grid.values[placement.Cell.Row][placement.Cell.Column] = placement.Digit - 1
+		grid.candidates[placement.Cell.Row][placement.Cell.Column] = 0
+	}
+	var step *Step = AvoidableRectangleType1.find(grid)
+	assert.Equal(t, []CellDigit{{Cell: Cell{Row: 1, Column: 3}, Digit: 1}}, step.Eliminations)
+
+	/*
+		Givens cannot be swapped, so they make no avoidable rectangle.
+	*/
+	grid.given[0][0] = true
+	assert.Nil(t, AvoidableRectangleType1.find(grid))
+
+	grid = openCandidateGrid()
+	for _, placement := range []CellDigit{{Cell: Cell{Row: 0, Column: 0}, Digit: 1}, {Cell: Cell{Row: 0, Column: 3}, Digit: 2}} {
+		grid.values[placement.Cell.Row][placement.Cell.Column] = placement.Digit - 1
+		grid.candidates[placement.Cell.Row][placement.Cell.Column] = 0
+	}
+	grid.candidates[1][0] = digitSetOf(1, 5)
+	grid.candidates[1][3] = digitSetOf(0, 5)
+	step = AvoidableRectangleType2.find(grid)
+	assert.Len(t, step.Eliminations, 7)
+	assert.Equal(t, []int{6}, eliminatedDigits(step))
+	assert.NotContains(t, eliminatedCells(step), Cell{Row: 1, Column: 0})
+}
+
+func Test_findBUGPlusOne(t *testing.T) {
+	assert.Nil(t, findBUGPlusOne(openCandidateGrid()))
+
+	/*
+		The emptied rectangle of 6s and 7s is a bivalue universal grave by itself, with nothing to
+		place; a third candidate that is not a candidate of three cells in each of its units does
+		not make it BUG+1.
+	*/
+	game, err := ParseGame(twoSolutionPuzzle)
+	assert.Nil(t, err)
+	grid, err := newCandidateGrid(game)
+	assert.Nil(t, err)
+	assert.Nil(t, findBUGPlusOne(grid))
+	var corner Cell = grid.cellsWith(0, 5)[0]
+	grid.candidates[corner.Row][corner.Column] = grid.get(corner).add(0)
+	assert.Nil(t, findBUGPlusOne(grid))
+
+	/*
+		1 is a candidate of three cells in each unit of r1c1, the cell with three candidates, but not
+		of r1c1 itself, so it cannot be placed there.
+	*/
+	grid = openCandidateGrid()
+	grid.candidates = [numRows][numColumns]digitSet{}
+	grid.candidates[0][0] = digitSetOf(2, 3, 4)
+	for _, cell := range []Cell{{Row: 0, Column: 1}, {Row: 3, Column: 0}} {
+		grid.candidates[cell.Row][cell.Column] = digitSetOf(0, 2)
+	}
+	grid.candidates[3][1] = digitSetOf(1, 2)
+	for _, cell := range []Cell{{Row: 0, Column: 3}, {Row: 0, Column: 4}, {Row: 1, Column: 0}, {Row: 1, Column: 1},
+		{Row: 4, Column: 0}, {Row: 3, Column: 3}, {Row: 4, Column: 4}} {
+		grid.candidates[cell.Row][cell.Column] = digitSetOf(0, 1)
+	}
+	for _, cell := range []Cell{{Row: 0, Column: 2}, {Row: 6, Column: 0}, {Row: 6, Column: 2}} {
+		grid.candidates[cell.Row][cell.Column] = digitSetOf(3, 4)
+	}
+	assert.Nil(t, findBUGPlusOne(grid))
+
+	/*
+		Solving this one by hand ends in BUG+1, with every cell but one down to two candidates.
+	*/
+	game, err = ParseGame(".75...3..8.1.3.54.......8....2..3......271..6.....6.9.4...2......8......6..9...28")
+	assert.Nil(t, err)
+	solution, _, err := CreateSolver().Solve(game)
+	assert.Nil(t, err)
+	grid, err = newCandidateGrid(game)
+	assert.Nil(t, err)
+	var solver *LogicalSolver = &LogicalSolver{}
+	for step := solver.next(grid); step != nil; step = solver.next(grid) {
+		if step.Technique == BUGPlusOne.Name {
+			var placement CellDigit = step.Placements[0]
+			assert.Equal(t, 3, grid.get(placement.Cell).count())
+			assert.Equal(t, solution.Grid[placement.Cell.Row][placement.Cell.Column]+1, placement.Digit)
+			return
+		}
+		grid.apply(step)
+	}
+	assert.Fail(t, "no BUG+1")
+}
+
+func TestLogicalSolver_Solve_uniqueness(t *testing.T) {
+	var uniqueness map[string]bool = make(map[string]bool)
+	for _, technique := range DefaultTechniques() {
+		if technique.RequiresUniqueness {
+			uniqueness[technique.Name] = true
+		}
+	}
+
+	for _, test := range []struct {
+		puzzle    string
+		technique string
+	}{
+		{"...4....7..89.....52.8..46.....4..3527...6..........9..67....2889....5.......7..4", UniqueRectangleType1.Name},
+		{"...4....7..89.....52.8..46.....4..3527...6..........9..67....2889....5.......7..4", UniqueRectangleType2.Name},
+		{".39..1.4..6..2...3.....4........5....9..6..3..7..4.16...32..5.9.5..1......8.....2", UniqueRectangleType3.Name},
+		{".25.6...39.6..8..2...2...6.......53.89.7...2.......1.9..18..7...4...1...3..5.....", UniqueRectangleType4.Name},
+		{"...71.9..7.3..5............8.6...5..1..4.3.8.....5..2...8.2....5...793...6.....4.", UniqueRectangleType6.Name},
+		{".7.36...9...8..5.19.1......8.....13.6............7...63....7..4..62.58....8.....3", AvoidableRectangleType1.Name},
+		{".75...3..8.1.3.54.......8....2..3......271..6.....6.9.4...2......8......6..9...28", BUGPlusOne.Name},
+	} {
+		game, err := ParseGame(test.puzzle)
+		assert.Nil(t, err)
+		solution, _, err := CreateSolver().Solve(game)
+		assert.Nil(t, err)
+		var expected string = FormatLine(solution)
+
+		_, steps, err := (&LogicalSolver{}).Solve(game)
+		assert.True(t, err == nil || err == ErrStuck, test.puzzle)
+		var used bool = false
+		for _, step := range steps {
+			used = used || step.Technique == test.technique
+			for _, placement := range step.Placements {
+				assert.Equal(t, expected[placement.Cell.Row*numColumns+placement.Cell.Column],
+					byte('0'+placement.Digit), step.String())
+			}
+			for _, elimination := range step.Eliminations {
+				assert.NotEqual(t, expected[elimination.Cell.Row*numColumns+elimination.Cell.Column],
+					byte('0'+elimination.Digit), step.String())
+			}
+		}
+		assert.True(t, used, test.technique)
+
+		/*
+			When the puzzle is not known to be unique the same solve does without them.
+		*/
+		_, steps, _ = (&LogicalSolver{SkipUniqueness: true}).Solve(game)
+		for _, step := range steps {
+			assert.False(t, uniqueness[step.Technique], step.String())
+		}
+	}
+}
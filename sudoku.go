@@ -1,19 +1,227 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"github.com/jkeene-NAN/sudoku/game"
 	"log"
+	"log/slog"
+	"os"
 )
 
+/*
+Usage: sudoku [-file puzzles.txt] [-dlx] [-seed n] [-timeout 10s] [-steps] [-progress] [-json] [-svg solution.svg] [-ascii] [-color] [-candidates] [puzzle]
+
+The puzzle is given in the 81 character line format.  Pass "-" to read it from standard input.
+With -file every puzzle in the file is solved in turn.  Files ending in .sdk or .ss are read as
+SadMan Sudoku and Simple Sudoku puzzles, anything else as one puzzle per line.
+With neither an empty grid is solved.
+With -dlx puzzles are solved with the Dancing Links exact cover engine instead of back tracking.
+Every solve logs the seed of its random choices; -seed replays a solve with that seed.
+With -timeout the solver gives up on a single puzzle after that long and shows how far it got.
+With -steps a single puzzle is first solved by hand, printing each technique applied.  Techniques
+that rely on a unique solution are only used when the puzzle has one.
+With -progress the solver logs how far it has got every so many iterations to standard error.
+With -json each result is written to standard output as a JSON document on its own line.
+With -svg the solution of a single puzzle is drawn to an SVG file.
+Solutions are drawn with Unicode box borders, or plain ASCII with -ascii.  -color marks givens,
+filled cells and conflicts with ANSI colors, and -candidates draws the puzzle with the candidates of
+every empty cell before solving it.
+
+Usage: sudoku booklet [flags] puzzles...
+
+Writes a printable PDF booklet, see booklet.go.
+*/
+/*
+singlePuzzleIterations is the iteration budget of a single puzzle, given directly or in a SadMan or
+Simple Sudoku file, the one it has always had.  The puzzles of a file of lines get the solver's
+default.
+*/
+const singlePuzzleIterations int = 100000000
+
 func main() {
+	log.SetOutput(os.Stdout)
+	if len(os.Args) > 1 && os.Args[1] == "booklet" {
+		booklet(os.Args[2:])
+		return
+	}
+
+	var fileName = flag.String("file", "", "solve every puzzle in a file with one puzzle per line")
+	var jsonOutput = flag.Bool("json", false, "write results to standard output as JSON")
+	var svgFileName = flag.String("svg", "", "draw the solution to an SVG file")
+	var ascii = flag.Bool("ascii", false, "draw boards with ASCII instead of Unicode borders")
+	var color = flag.Bool("color", false, "color givens, filled cells and conflicts")
+	var candidates = flag.Bool("candidates", false, "draw the puzzle with its candidates before solving")
+	var dlx = flag.Bool("dlx", false, "solve with the Dancing Links engine")
+	var seed = flag.Int64("seed", 0, "seed for the solver's random choices, random when 0")
+	var timeout = flag.Duration("timeout", 0, "give up on a single puzzle after this long")
+	var explain = flag.Bool("steps", false, "print the steps that solve a single puzzle without guessing")
+	var progress = flag.Bool("progress", false, "log the progress of each solve")
+	flag.Parse()
+
+	var solver *game.Solver = game.CreateSolver()
+	solver.Seed = *seed
+	if *dlx {
+		solver.Engine = game.DLXEngine{}
+	}
+	if *progress {
+		solver.IterationReportInterval = 10000
+		solver.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	var textOptions *game.TextOptions = &game.TextOptions{ASCII: *ascii, Color: *color}
+	var report reporter = textResult(textOptions)
+	if *jsonOutput {
+		report = jsonResult(json.NewEncoder(os.Stdout))
+	}
+
 	log.Print("commencing")
+	if *fileName != "" {
+		solveFile(*fileName, solver, report)
+		log.Print("done")
+		return
+	}
+
 	var initialGame *game.Game
-	initialGame = game.NewGame()
-	gameState, iterations, err := game.PlayGame(initialGame, 100000000)
+	var err error
+
+	switch {
+	case flag.NArg() < 1:
+		initialGame = game.NewGame()
+	case flag.Arg(0) == "-":
+		initialGame, err = game.ReadGame(os.Stdin)
+	default:
+		initialGame, err = game.ParseGame(flag.Arg(0))
+	}
+
 	if err != nil {
-		log.Printf("error playing game: %v", err)
-	} else {
-		log.Printf("iterations: %d, gameState: %s", iterations, gameState.String())
+		log.Fatalf("error reading puzzle: %v", err)
+	}
+
+	if *candidates {
+		printText(initialGame, &game.TextOptions{ASCII: *ascii, Color: *color, PencilMarks: true})
+	}
+
+	if *explain {
+		printSteps(initialGame)
+	}
+
+	var ctx context.Context = context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	solver.MaximumIterations = singlePuzzleIterations
+	solution, statistics, err := solver.SolveContext(ctx, initialGame)
+	report(&game.Puzzle{Game: initialGame}, solution, statistics, err)
+	if err == nil && *svgFileName != "" {
+		writeSVG(*svgFileName, solution)
 	}
 	log.Print("done")
 }
+
+/*
+printSteps solves puzzle with the LogicalSolver and prints every step, and how far it got when it
+gets stuck.  The uniqueness techniques are left out unless the puzzle has exactly one solution.
+*/
+func printSteps(puzzle *game.Game) {
+	unique, _ := game.CreateSolver().HasUniqueSolution(puzzle)
+	partial, steps, err := (&game.LogicalSolver{SkipUniqueness: !unique}).Solve(puzzle)
+	for i, step := range steps {
+		fmt.Printf("%3d. %s\n", i+1, step)
+	}
+	if err != nil {
+		log.Printf("solving by hand stopped: %v", err)
+		if partial != nil {
+			printText(partial, &game.TextOptions{PencilMarks: true})
+		}
+	}
+}
+
+/*
+printText draws board on standard output, logging the reason when it cannot be drawn.
+*/
+func printText(board *game.Game, options *game.TextOptions) {
+	if err := game.WriteText(os.Stdout, board, options); err != nil {
+		log.Printf("error drawing board: %v", err)
+	}
+}
+
+type reporter func(puzzle *game.Puzzle, solution *game.Game, statistics *game.GamePlayStatistics, err error)
+
+func textResult(options *game.TextOptions) reporter {
+	return func(puzzle *game.Puzzle, solution *game.Game, statistics *game.GamePlayStatistics, err error) {
+		var prefix string = puzzle.Name
+		if prefix == "" {
+			prefix = "puzzle"
+		}
+		if puzzle.Line != 0 {
+			prefix = fmt.Sprintf("line %d %s", puzzle.Line, prefix)
+		}
+
+		if err != nil {
+			log.Printf("%s: error solving puzzle: %v", prefix, err)
+			if solution != nil {
+				printText(solution, options)
+			}
+		} else {
+			log.Printf("%s: iterations: %d, back tracks: %d, max depth: %d, wall time: %s, seed: %d", prefix,
+				statistics.Iterations, statistics.BackTracks, statistics.MaxDepth, statistics.WallTime, statistics.Seed)
+			printText(solution, options)
+		}
+	}
+}
+
+func jsonResult(encoder *json.Encoder) reporter {
+	return func(puzzle *game.Puzzle, solution *game.Game, statistics *game.GamePlayStatistics, err error) {
+		var encodeErr error = encoder.Encode(game.NewSolveResult(puzzle.Game, solution, statistics, err))
+		if encodeErr != nil {
+			log.Printf("error writing result: %v", encodeErr)
+		}
+	}
+}
+
+func writeSVG(fileName string, solution *game.Game) {
+	file, err := os.Create(fileName)
+	if err != nil {
+		log.Fatalf("error creating svg: %v", err)
+	}
+	defer file.Close()
+
+	err = game.WriteSVG(file, solution, nil)
+	if err != nil {
+		log.Fatalf("error writing svg: %v", err)
+	}
+}
+
+func solveFile(fileName string, solver *game.Solver, report reporter) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatalf("error opening puzzles: %v", err)
+	}
+	defer file.Close()
+
+	var format game.Format = game.FormatForFileName(fileName)
+	if format == game.SadManFormat || format == game.SimpleSudokuFormat {
+		games, err := game.ReadFormat(file, format)
+		if err != nil {
+			log.Fatalf("error reading puzzle: %v", err)
+		}
+		solver.MaximumIterations = singlePuzzleIterations
+		solution, statistics, err := solver.Solve(games[0])
+		report(&game.Puzzle{Game: games[0]}, solution, statistics, err)
+		return
+	}
+
+	err = solver.SolveEach(file,
+		func(puzzle *game.Puzzle, solution *game.Game, statistics *game.GamePlayStatistics, err error) error {
+			report(puzzle, solution, statistics, err)
+			return nil
+		})
+	if err != nil {
+		log.Fatalf("error solving puzzles: %v", err)
+	}
+}